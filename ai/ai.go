@@ -7,14 +7,12 @@ import (
 )
 
 func (n *NetWrapper) Play(g snake.GameState) snake.Move {
-	vis := g.Vision(n.ID)
+	vis := g.Inputs(n.ID)
 	life := g.Life(n.ID)
-	inf := make([]float64, len(vis))
+	inf := make([]float64, 0, len(vis)+1)
+	inf = append(inf, vis...)
 	inf = append(inf, life)
 
-	for i := range vis {
-		inf[i] = float64(vis[i])
-	}
 	in := mat.NewDense(1, len(inf), inf)
 	out, err := n.Ai.Activate(in)
 	if err != nil {
@@ -28,12 +26,26 @@ func (n *NetWrapper) SetID(id snake.ID) {
 	n.ID = id
 }
 
+// VisionProvider returns the vision the network was trained with, nil uses the game's vision
+func (n *NetWrapper) VisionProvider() snake.VisionProvider {
+	return n.Vision
+}
+
 type NetWrapper struct {
 	Ai     evo.Network
 	ID     snake.ID
+	Vision snake.VisionProvider
 	maxLen int
 }
 
+// NumInputs is the number of inputs of a network playing with vision v, the life of the snake is the last input
+func NumInputs(v snake.VisionProvider) int {
+	if v == nil {
+		v = snake.DefaultVision
+	}
+	return v.Size() + 1
+}
+
 func (e Evaluator) Evaluate(p evo.Phenome) (r evo.Result, err error) {
 
 	r.ID = p.ID
@@ -42,7 +54,7 @@ func (e Evaluator) Evaluate(p evo.Phenome) (r evo.Result, err error) {
 		out *mat.Dense
 	}
 
-	player := NetWrapper{Ai: p.Network}
+	player := NetWrapper{Ai: p.Network, Vision: e.Vision}
 	g, err := snake.NewGame(20, 20, []snake.Player{
 		&player,
 	}, 1)
@@ -68,4 +80,6 @@ func (e Evaluator) Evaluate(p evo.Phenome) (r evo.Result, err error) {
 }
 
 type Evaluator struct {
+	// Vision is the vision the networks are trained with, nil uses snake.DefaultVision
+	Vision snake.VisionProvider
 }
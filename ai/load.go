@@ -0,0 +1,74 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/klokare/evo"
+	"github.com/wouterbeets/snake"
+)
+
+// Translator turns a substrate into a network, neat.Experiment is one
+type Translator interface {
+	Translate(evo.Substrate) (evo.Network, error)
+}
+
+// LoadNetworks reads a json file of substrates like the ones saved by the commands
+func LoadNetworks(t Translator, path string) ([]evo.Network, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var sub []evo.Substrate
+	if err := json.Unmarshal(b, &sub); err != nil {
+		return nil, err
+	}
+	nets := make([]evo.Network, 0, len(sub))
+	for _, s := range sub {
+		net, err := t.Translate(s)
+		if err != nil {
+			return nil, err
+		}
+		nets = append(nets, net)
+	}
+	return nets, nil
+}
+
+// ConfigFile writes a copy of the neat configuration at path with num-inputs set
+// for vision v and returns the path of the copy, it is removed by calling remove
+func ConfigFile(path string, v snake.VisionProvider) (copyPath string, remove func(), err error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", nil, err
+	}
+	var cfg map[string]interface{}
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return "", nil, fmt.Errorf("reading config %s: %v", path, err)
+	}
+	n, ok := cfg["neat"].(map[string]interface{})
+	if !ok {
+		return "", nil, fmt.Errorf("config %s has no neat section", path)
+	}
+	n["num-inputs"] = NumInputs(v)
+	if b, err = json.MarshalIndent(cfg, "", "\t"); err != nil {
+		return "", nil, err
+	}
+
+	file, err := ioutil.TempFile("", "genn-*.json")
+	if err != nil {
+		return "", nil, err
+	}
+	remove = func() { os.Remove(file.Name()) }
+	if _, err := file.Write(b); err != nil {
+		file.Close()
+		remove()
+		return "", nil, err
+	}
+	if err := file.Close(); err != nil {
+		remove()
+		return "", nil, err
+	}
+	return file.Name(), remove, nil
+}
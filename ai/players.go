@@ -0,0 +1,151 @@
+package ai
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/klokare/evo"
+	"github.com/klokare/evo/config"
+	"github.com/klokare/evo/config/source"
+	"github.com/klokare/evo/neat"
+	"github.com/wouterbeets/snake"
+	"github.com/wouterbeets/snake/bots"
+	"github.com/wouterbeets/snake/qlearn"
+)
+
+// PlayerUsage describes the names ParsePlayers knows, for the help of the commands
+var PlayerUsage = "comma separated players, random, " + strings.Join(bots.Names(), ", ") +
+	", remote, exec:command, q:table.json or a substrate file, file.json:i only uses the i-th network of the file"
+
+// PlayerSpec is a player named on the command line
+type PlayerSpec struct {
+	Name string
+	// New returns the player for a game
+	New func() snake.Player
+}
+
+// SpecOptions make the players that need more than their name
+type SpecOptions struct {
+	// LoadNetworks reads a substrate file, it is only called for names no other player has
+	LoadNetworks func(path string) ([]evo.Network, error)
+	// Accept returns a remote player, it plays all games over the same connection.
+	// remote is refused when Accept is nil
+	Accept func() (snake.Player, error)
+	// Vision is the vision of networks and exec players
+	Vision snake.VisionProvider
+	// Stderr gets what exec players write to their stderr
+	Stderr io.Writer
+	// ProcessPerGame starts a process for every call of New of an exec player, the caller closes
+	// them after the game. Otherwise one process plays all games, which only works one game at a time
+	ProcessPerGame bool
+}
+
+// ParsePlayers reads a comma separated list of players, a substrate file without index
+// gives a player for every network of the file
+func ParsePlayers(spec string, opts SpecOptions) ([]PlayerSpec, error) {
+	var specs []PlayerSpec
+	for _, s := range strings.Split(spec, ",") {
+		s = strings.TrimSpace(s)
+		switch {
+		case s == "":
+			continue
+		case s == "random":
+			specs = append(specs, PlayerSpec{Name: s, New: func() snake.Player { return &snake.Random{} }})
+			continue
+		case s == "remote":
+			if opts.Accept == nil {
+				return nil, fmt.Errorf("player %q: remote players can't play here", s)
+			}
+			p, err := opts.Accept()
+			if err != nil {
+				return nil, fmt.Errorf("player %q: %v", s, err)
+			}
+			specs = append(specs, PlayerSpec{Name: s, New: func() snake.Player { return p }})
+			continue
+		case strings.HasPrefix(s, "exec:"):
+			args := strings.Fields(strings.TrimPrefix(s, "exec:"))
+			if len(args) == 0 {
+				return nil, fmt.Errorf("player %q: no command", s)
+			}
+			newProcess := func() snake.Player {
+				p := snake.NewProcessPlayer(args[0], args[1:]...)
+				p.Vision = opts.Vision
+				p.Stderr = opts.Stderr
+				return p
+			}
+			if !opts.ProcessPerGame {
+				p := newProcess()
+				newProcess = func() snake.Player { return p }
+			}
+			specs = append(specs, PlayerSpec{Name: s, New: newProcess})
+			continue
+		case strings.HasPrefix(s, "q:"):
+			t, err := qlearn.Load(strings.TrimPrefix(s, "q:"))
+			if err != nil {
+				return nil, fmt.Errorf("player %q: %v", s, err)
+			}
+			specs = append(specs, PlayerSpec{Name: s, New: func() snake.Player { return &qlearn.Agent{Table: t} }})
+			continue
+		}
+		if _, err := bots.New(s); err == nil {
+			name := s
+			specs = append(specs, PlayerSpec{Name: name, New: func() snake.Player {
+				p, _ := bots.New(name)
+				return p
+			}})
+			continue
+		}
+
+		path, index := s, -1
+		if i := strings.LastIndex(s, ":"); i >= 0 {
+			n, err := strconv.Atoi(s[i+1:])
+			if err != nil {
+				return nil, fmt.Errorf("player %q: bad network index: %v", s, err)
+			}
+			path, index = s[:i], n
+		}
+		if opts.LoadNetworks == nil {
+			return nil, fmt.Errorf("player %q: unknown player", s)
+		}
+		nets, err := opts.LoadNetworks(path)
+		if err != nil {
+			return nil, fmt.Errorf("player %q: %v", s, err)
+		}
+		if index >= len(nets) {
+			return nil, fmt.Errorf("player %q: file has %d networks", s, len(nets))
+		}
+		for i, n := range nets {
+			if index >= 0 && i != index {
+				continue
+			}
+			net := n
+			specs = append(specs, PlayerSpec{
+				Name: fmt.Sprintf("%s:%d", path, i),
+				New:  func() snake.Player { return &NetWrapper{Ai: net, Vision: opts.Vision} },
+			})
+		}
+	}
+	return specs, nil
+}
+
+// NetworkLoader returns a LoadNetworks that translates the substrates with the neat
+// configuration at configPath, the configuration is read on the first call
+func NetworkLoader(configPath string) func(path string) ([]evo.Network, error) {
+	var exp *neat.Experiment
+	return func(path string) ([]evo.Network, error) {
+		if exp == nil {
+			src, err := source.NewJSONFromFile(configPath)
+			if err != nil {
+				return nil, err
+			}
+			exp = neat.NewExperiment(config.Configurer{Source: source.Multi([]config.Source{
+				source.Flag{},
+				source.Environment{},
+				src,
+			})})
+		}
+		return LoadNetworks(exp, path)
+	}
+}
@@ -0,0 +1,67 @@
+package ai
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/klokare/evo"
+	"github.com/stretchr/testify/require"
+	"github.com/wouterbeets/snake"
+	"github.com/wouterbeets/snake/bots"
+	"github.com/wouterbeets/snake/qlearn"
+)
+
+func names(specs []PlayerSpec) []string {
+	var names []string
+	for _, s := range specs {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+func TestParsePlayers(t *testing.T) {
+	opts := SpecOptions{LoadNetworks: func(path string) ([]evo.Network, error) {
+		if path != "ai.json" {
+			return nil, errors.New("no such file")
+		}
+		return make([]evo.Network, 3), nil
+	}}
+	specs, err := ParsePlayers("random, ai.json,survivor,ai.json:1", opts)
+	require.NoError(t, err)
+	require.Equal(t, []string{"random", "ai.json:0", "ai.json:1", "ai.json:2", "survivor", "ai.json:1"}, names(specs))
+	require.IsType(t, &snake.Random{}, specs[0].New())
+	require.IsType(t, &NetWrapper{}, specs[1].New())
+	require.IsType(t, &bots.Survivor{}, specs[4].New())
+
+	specs, err = ParsePlayers("", opts)
+	require.NoError(t, err)
+	require.Empty(t, specs)
+	for _, bad := range []string{"ai.json:3", "ai.json:x", "end.json", "exec: ", "q:missing.json", "remote"} {
+		_, err = ParsePlayers(bad, opts)
+		require.Error(t, err, bad)
+	}
+	_, err = ParsePlayers("ai.json", SpecOptions{})
+	require.Error(t, err)
+}
+
+func TestParsePlayersShared(t *testing.T) {
+	remote := &snake.Random{}
+	opts := SpecOptions{Accept: func() (snake.Player, error) { return remote, nil }}
+	specs, err := ParsePlayers("remote,exec:python3 policy.py", opts)
+	require.NoError(t, err)
+	require.Equal(t, []string{"remote", "exec:python3 policy.py"}, names(specs))
+	require.True(t, specs[0].New() == snake.Player(remote))
+	// one process plays all games unless every game gets its own
+	require.True(t, specs[1].New() == specs[1].New())
+	opts.ProcessPerGame = true
+	specs, err = ParsePlayers("exec:python3 policy.py", opts)
+	require.NoError(t, err)
+	require.False(t, specs[0].New() == specs[0].New())
+
+	path := filepath.Join(t.TempDir(), "q.json")
+	require.NoError(t, qlearn.Table{"222+0": {0, 1, 0}}.Save(path))
+	specs, err = ParsePlayers("q:"+path, opts)
+	require.NoError(t, err)
+	require.IsType(t, &qlearn.Agent{}, specs[0].New())
+}
@@ -1,49 +1,93 @@
 package ai
 
 import (
+	"context"
+
 	"github.com/klokare/evo"
 	"github.com/wouterbeets/snake"
 )
 
+// Trainer lets all phenomes play one battle royale game against each other
 type Trainer struct {
+	// KillReward is added to the fitness for every snake killed
+	KillReward float64
+	// SelfPenalty is subtracted from the fitness of snakes that ran into themselves
+	SelfPenalty float64
+	// Vision is the vision the networks are trained with, nil uses snake.DefaultVision
+	Vision snake.VisionProvider
+	// Sparring creates players that join every game next to the networks, they get no result
+	Sparring []func() snake.Player
 }
 
 // Search doesn't use the eval fuction
 func (s Trainer) Search(eval evo.Evaluator, phenomes []evo.Phenome) (results []evo.Result, err error) {
-	players := make(map[int64]snake.Player, len(phenomes))
 	var playerSlice []snake.Player
 	for _, p := range phenomes {
-		ai := &NetWrapper{Ai: p.Network}
-		players[p.ID] = ai
-		playerSlice = append(playerSlice, ai)
+		playerSlice = append(playerSlice, &NetWrapper{Ai: p.Network, Vision: s.Vision})
+	}
+
+	nets := playerSlice
+	for _, sparring := range s.Sparring {
+		playerSlice = append(playerSlice, sparring())
+	}
+
+	size := len(playerSlice) * 3
+	g, err := snake.NewGame(size, size, playerSlice, len(playerSlice)*20)
+	if err != nil {
+		return nil, err
+	}
+
+	// players are keyed on their id in the game
+	players := make(map[snake.ID]*NetWrapper, len(phenomes))
+	phenomeIDs := make(map[snake.ID]int64, len(phenomes))
+	for i, p := range nets {
+		player := p.(*NetWrapper)
+		player.maxLen = g.PlayerLen(player.ID)
+		players[player.ID] = player
+		phenomeIDs[player.ID] = phenomes[i].ID
 	}
 
-	g, _ := snake.NewGame(len(players)*3, len(players)*3, playerSlice, len(players)*20)
+	// the game stops once the last network died, the sparring players don't need to finish it
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	alive := len(players)
+
 	rounds := 100000
-	for i := 0; i < rounds; i++ {
-		gameOver, _ := g.PlayRound()
-		for id, player := range players {
-			// if we have performance issues we can implement a Dead() interface
-			// this interface could allow us to notify which snake is dead by sending their id on a channel
-			pl := g.PlayerLen(player.(*NetWrapper).ID)
-			if pl > player.(*NetWrapper).maxLen {
-				player.(*NetWrapper).maxLen = pl
+	g.Subscribe(func(e snake.Event) {
+		player, ok := players[e.ID]
+		if !ok {
+			return
+		}
+		switch e.Type {
+		case snake.AteFood:
+			if pl := g.PlayerLen(e.ID); pl > player.maxLen {
+				player.maxLen = pl
 			}
-			if !g.Alive(player.(*NetWrapper).ID) {
-				// add result
-				fit := float64(i) / float64(rounds)
-				maxLen := float64(player.(*NetWrapper).maxLen)
-				r := evo.Result{
-					ID:      id,
-					Fitness: fit + maxLen/10,
-				}
-				results = append(results, r)
-				delete(players, id)
+		case snake.Died:
+			if alive--; alive == 0 {
+				cancel()
 			}
 		}
-		if len(players) == 0 || gameOver {
-			return results, nil
+	})
+	if _, err := g.Run(ctx, snake.RunOptions{MaxTicks: rounds}); err != nil && err != context.Canceled {
+		return nil, err
+	}
+
+	// the results are added once the game is done so kills made in the tick a snake died count
+	for id, d := range g.Deaths() {
+		if _, ok := players[id]; !ok {
+			continue
+		}
+		fit := float64(d.Tick) / float64(rounds)
+		maxLen := float64(players[id].maxLen)
+		fit += maxLen/10 + float64(g.Kills(id))*s.KillReward
+		if d.Cause == snake.HitSelf {
+			fit -= s.SelfPenalty
 		}
+		results = append(results, evo.Result{
+			ID:      phenomeIDs[id],
+			Fitness: fit,
+		})
 	}
-	return
+	return results, nil
 }
@@ -0,0 +1,41 @@
+package ai
+
+import (
+	"testing"
+
+	"github.com/klokare/evo"
+	"github.com/stretchr/testify/require"
+	"github.com/wouterbeets/snake"
+	"github.com/wouterbeets/snake/bots"
+	"gonum.org/v1/gonum/mat"
+)
+
+// straightNet always goes straight
+type straightNet struct{}
+
+func (straightNet) Activate(mat.Matrix) (mat.Matrix, error) {
+	return mat.NewDense(1, 3, []float64{0, 1, 0}), nil
+}
+
+// countingPlayer counts the moves asked from a player
+type countingPlayer struct {
+	snake.Player
+	plays int
+}
+
+func (p *countingPlayer) Play(gs snake.GameState) snake.Move {
+	p.plays++
+	return p.Player.Play(gs)
+}
+
+func TestSearchStopsWithNetworks(t *testing.T) {
+	sparring := &countingPlayer{Player: &bots.Hamilton{}}
+	tr := Trainer{Sparring: []func() snake.Player{func() snake.Player { return sparring }}}
+	phenomes := []evo.Phenome{{ID: 1, Network: straightNet{}}, {ID: 2, Network: straightNet{}}, {ID: 3, Network: straightNet{}}}
+	results, err := tr.Search(nil, phenomes)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	// going straight hits a wall of the 12 by 12 board, the game ends with the networks
+	require.True(t, sparring.plays < 12, "the sparring player played %d ticks", sparring.plays)
+}
@@ -0,0 +1,391 @@
+// Package api serves games over http with json so programs in other languages
+// can play them one tick at a time
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/wouterbeets/snake"
+	"github.com/wouterbeets/snake/bots"
+)
+
+// External is the player name of seats whose moves are posted to the api
+const External = "external"
+
+// The limits of a Server whose own limits are 0
+const (
+	DefaultMaxSide    = 100
+	DefaultMaxPlayers = 16
+	DefaultMaxTicks   = 1000
+	DefaultMaxGames   = 100
+)
+
+// Server is a http.Handler over a set of games
+//
+//	POST   /games                       create a game from a GameRequest
+//	GET    /games                       ids of the games
+//	GET    /games/{id}                  State of the game
+//	DELETE /games/{id}                  remove the game
+//	GET    /games/{id}/board            board of the game
+//	GET    /games/{id}/players          PlayerStats of all players
+//	GET    /games/{id}/players/{player} PlayerStats of one player
+//	POST   /games/{id}/moves            moves of external players for the next tick, {"2":"l","3":"r"}
+//	POST   /games/{id}/tick?n=1         play n ticks and return the State
+//
+// Games are kept until they are deleted, at most MaxGames at once. A deleted game
+// closes the players that are an io.Closer
+type Server struct {
+	// NewPlayer returns the player for a name of a GameRequest,
+	// nil knows random and the names of the bots package
+	NewPlayer func(name string) (snake.Player, error)
+	// MaxSide is the largest height and width of a board
+	MaxSide int
+	// MaxPlayers is the most players of a game, at most snake.MaxPlayers
+	MaxPlayers int
+	// MaxTicks is the most ticks played by one tick request
+	MaxTicks int
+	// MaxGames is the most games kept at once, creating more fails until games are deleted
+	MaxGames int
+
+	mux   *http.ServeMux
+	once  sync.Once
+	mu    sync.Mutex
+	games map[string]*game
+	next  int
+}
+
+// GameRequest describes a game to create, Players holds player names or External
+type GameRequest struct {
+	Height  int      `json:"height"`
+	Width   int      `json:"width"`
+	Food    int      `json:"food"`
+	Seed    int64    `json:"seed"`
+	Players []string `json:"players"`
+}
+
+// State is a game after the last tick
+type State struct {
+	ID       string        `json:"id"`
+	Tick     int           `json:"tick"`
+	GameOver bool          `json:"gameOver"`
+	Board    snake.Board   `json:"board"`
+	Players  []PlayerStats `json:"players"`
+}
+
+// PlayerStats describes a player, Cause, Killer and DeathTick are only set for dead players
+type PlayerStats struct {
+	ID        snake.ID `json:"id"`
+	Name      string   `json:"name"`
+	Alive     bool     `json:"alive"`
+	Length    int      `json:"length"`
+	Life      float64  `json:"life"`
+	Cause     string   `json:"cause,omitempty"`
+	Killer    snake.ID `json:"killer,omitempty"`
+	DeathTick int      `json:"deathTick,omitempty"`
+}
+
+type game struct {
+	mu       sync.Mutex
+	id       string
+	g        *snake.Game
+	names    []string
+	players  []snake.Player
+	external map[snake.ID]*external
+	over     bool
+}
+
+// external plays the move posted for the tick, it goes straight when there is none
+type external struct {
+	ID   snake.ID
+	move []float64
+}
+
+func (e *external) Play(snake.GameState) snake.Move {
+	m := snake.Move{Move: e.move, ID: e.ID}
+	if m.Move == nil {
+		m.Move = []float64{0, 1, 0}
+	}
+	return m
+}
+
+func (e *external) SetID(id snake.ID) {
+	e.ID = id
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.once.Do(func() {
+		s.games = make(map[string]*game)
+		s.mux = http.NewServeMux()
+		s.mux.HandleFunc("POST /games", s.create)
+		s.mux.HandleFunc("GET /games", s.list)
+		s.mux.HandleFunc("GET /games/{id}", s.withGame(s.state))
+		s.mux.HandleFunc("DELETE /games/{id}", s.remove)
+		s.mux.HandleFunc("GET /games/{id}/board", s.withGame(s.board))
+		s.mux.HandleFunc("GET /games/{id}/players", s.withGame(s.players))
+		s.mux.HandleFunc("GET /games/{id}/players/{player}", s.withGame(s.player))
+		s.mux.HandleFunc("POST /games/{id}/moves", s.withGame(s.moves))
+		s.mux.HandleFunc("POST /games/{id}/tick", s.withGame(s.tick))
+	})
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) newPlayer(name string) (snake.Player, error) {
+	if s.NewPlayer != nil {
+		return s.NewPlayer(name)
+	}
+	if name == "random" {
+		return &snake.Random{}, nil
+	}
+	return bots.New(name)
+}
+
+func (s *Server) create(w http.ResponseWriter, r *http.Request) {
+	var req GameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := s.check(req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	gm := &game{names: req.Players, external: make(map[snake.ID]*external)}
+	players := make([]snake.Player, len(req.Players))
+	for i, name := range req.Players {
+		if name == External {
+			e := &external{}
+			gm.external[snake.ID(i+2)] = e
+			players[i] = e
+			continue
+		}
+		p, err := s.newPlayer(name)
+		if err != nil {
+			closePlayers(players)
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		players[i] = p
+	}
+	g, err := snake.NewGameWithConfig(snake.GameConfig{
+		Height:      req.Height,
+		Width:       req.Width,
+		Players:     players,
+		NbFoodOnMap: req.Food,
+		Seed:        req.Seed,
+	})
+	if err != nil {
+		closePlayers(players)
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	gm.g, gm.players = g, players
+
+	s.mu.Lock()
+	if max := limit(s.MaxGames, DefaultMaxGames); len(s.games) >= max {
+		s.mu.Unlock()
+		closePlayers(players)
+		writeError(w, http.StatusServiceUnavailable, fmt.Errorf("the server holds %d games, delete some first", max))
+		return
+	}
+	s.next++
+	gm.id = strconv.Itoa(s.next)
+	s.games[gm.id] = gm
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, gm.state())
+}
+
+// check refuses games beyond the limits of the server
+func (s *Server) check(req GameRequest) error {
+	side := limit(s.MaxSide, DefaultMaxSide)
+	if req.Height > side || req.Width > side {
+		return fmt.Errorf("a board is at most %d by %d", side, side)
+	}
+	if players := limit(s.MaxPlayers, DefaultMaxPlayers); len(req.Players) > players {
+		return fmt.Errorf("a game has at most %d players", players)
+	}
+	return nil
+}
+
+// limit returns max, or def when max is 0
+func limit(max, def int) int {
+	if max <= 0 {
+		return def
+	}
+	return max
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	ids := make([]string, 0, len(s.games))
+	for id := range s.games {
+		ids = append(ids, id)
+	}
+	s.mu.Unlock()
+	sort.Slice(ids, func(i, j int) bool {
+		a, _ := strconv.Atoi(ids[i])
+		b, _ := strconv.Atoi(ids[j])
+		return a < b
+	})
+	writeJSON(w, http.StatusOK, ids)
+}
+
+func (s *Server) remove(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	id := r.PathValue("id")
+	gm, ok := s.games[id]
+	delete(s.games, id)
+	s.mu.Unlock()
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no game %q", id))
+		return
+	}
+	// a tick still playing finishes before the players stop
+	gm.mu.Lock()
+	closePlayers(gm.players)
+	gm.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// withGame looks up the game of the request and locks it for h
+func (s *Server) withGame(h func(http.ResponseWriter, *http.Request, *game)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		gm, ok := s.games[r.PathValue("id")]
+		s.mu.Unlock()
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Errorf("no game %q", r.PathValue("id")))
+			return
+		}
+		gm.mu.Lock()
+		defer gm.mu.Unlock()
+		h(w, r, gm)
+	}
+}
+
+func (s *Server) state(w http.ResponseWriter, r *http.Request, gm *game) {
+	writeJSON(w, http.StatusOK, gm.state())
+}
+
+func (s *Server) board(w http.ResponseWriter, r *http.Request, gm *game) {
+	writeJSON(w, http.StatusOK, gm.g.Board())
+}
+
+func (s *Server) players(w http.ResponseWriter, r *http.Request, gm *game) {
+	writeJSON(w, http.StatusOK, gm.stats())
+}
+
+func (s *Server) player(w http.ResponseWriter, r *http.Request, gm *game) {
+	id, err := strconv.Atoi(r.PathValue("player"))
+	if err != nil || id < 2 || id-2 >= len(gm.names) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no player %q", r.PathValue("player")))
+		return
+	}
+	writeJSON(w, http.StatusOK, gm.stats()[id-2])
+}
+
+func (s *Server) moves(w http.ResponseWriter, r *http.Request, gm *game) {
+	var moves map[snake.ID]string
+	if err := json.NewDecoder(r.Body).Decode(&moves); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	// all moves are checked before any is taken
+	choices := make(map[snake.ID][]float64, len(moves))
+	for id, m := range moves {
+		if _, ok := gm.external[id]; !ok {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("player %d is not external", id))
+			return
+		}
+		switch m {
+		case "l":
+			choices[id] = []float64{1, 0, 0}
+		case "s":
+			choices[id] = []float64{0, 1, 0}
+		case "r":
+			choices[id] = []float64{0, 0, 1}
+		default:
+			writeError(w, http.StatusBadRequest, fmt.Errorf("player %d: move %q is not l, s or r", id, m))
+			return
+		}
+	}
+	for id, c := range choices {
+		gm.external[id].move = c
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) tick(w http.ResponseWriter, r *http.Request, gm *game) {
+	n := 1
+	if q := r.URL.Query().Get("n"); q != "" {
+		var err error
+		max := limit(s.MaxTicks, DefaultMaxTicks)
+		if n, err = strconv.Atoi(q); err != nil || n < 1 || n > max {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("n must be between 1 and %d", max))
+			return
+		}
+	}
+	for i := 0; i < n && !gm.over; i++ {
+		gm.over, _ = gm.g.PlayRound()
+		// a posted move is only played once
+		for _, e := range gm.external {
+			e.move = nil
+		}
+	}
+	writeJSON(w, http.StatusOK, gm.state())
+}
+
+func (gm *game) state() State {
+	return State{
+		ID:       gm.id,
+		Tick:     gm.g.Tick(),
+		GameOver: gm.over,
+		Board:    gm.g.Board(),
+		Players:  gm.stats(),
+	}
+}
+
+func (gm *game) stats() []PlayerStats {
+	stats := make([]PlayerStats, len(gm.names))
+	for i, name := range gm.names {
+		id := snake.ID(i + 2)
+		st := PlayerStats{ID: id, Name: name, Alive: gm.g.Alive(id)}
+		if st.Alive {
+			st.Length = gm.g.PlayerLen(id)
+			st.Life = gm.g.Life(id)
+		} else if d, ok := gm.g.Death(id); ok {
+			st.Length = d.Length
+			st.Cause = d.Cause.String()
+			st.Killer = d.KillerID
+			st.DeathTick = d.Tick
+		}
+		stats[i] = st
+	}
+	return stats
+}
+
+// closePlayers stops the players that hold a process or a connection
+func closePlayers(players []snake.Player) {
+	for _, p := range players {
+		if c, ok := p.(io.Closer); ok {
+			c.Close()
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,176 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wouterbeets/snake"
+	"github.com/wouterbeets/snake/bots"
+)
+
+// do sends a request with body encoded as json and decodes the answer into out
+func do(t *testing.T, h http.Handler, method, path string, body, out interface{}) int {
+	var buf bytes.Buffer
+	if body != nil {
+		require.NoError(t, json.NewEncoder(&buf).Encode(body))
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(method, path, &buf))
+	if out != nil && rec.Body.Len() > 0 {
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(out))
+	}
+	return rec.Code
+}
+
+func TestServer(t *testing.T) {
+	s := &Server{}
+	var st State
+	req := GameRequest{Height: 15, Width: 15, Food: 3, Seed: 1, Players: []string{External, "greedy"}}
+	require.Equal(t, http.StatusCreated, do(t, s, "POST", "/games", req, &st))
+	require.Equal(t, "1", st.ID)
+	require.Equal(t, 0, st.Tick)
+	require.Len(t, st.Board, 15)
+	require.Len(t, st.Players, 2)
+	require.Equal(t, PlayerStats{ID: 2, Name: External, Alive: true, Length: 2, Life: 1}, st.Players[0])
+
+	var ids []string
+	require.Equal(t, http.StatusOK, do(t, s, "GET", "/games", nil, &ids))
+	require.Equal(t, []string{"1"}, ids)
+
+	// the external player turns where it is told, the same game played locally agrees
+	local := &turner{moves: []string{"l", "s", "r"}}
+	g, err := snake.NewGameWithConfig(snake.GameConfig{Height: 15, Width: 15, NbFoodOnMap: 3, Seed: 1,
+		Players: []snake.Player{local, &bots.Greedy{}}})
+	require.NoError(t, err)
+	for _, m := range local.moves {
+		require.Equal(t, http.StatusNoContent, do(t, s, "POST", "/games/1/moves", map[string]string{"2": m}, nil))
+		require.Equal(t, http.StatusOK, do(t, s, "POST", "/games/1/tick", nil, &st))
+		g.PlayRound()
+	}
+	require.Equal(t, 3, st.Tick)
+	require.Equal(t, g.Board(), st.Board)
+
+	// without a posted move the player goes straight
+	require.Equal(t, http.StatusOK, do(t, s, "POST", "/games/1/tick?n=2", nil, &st))
+	require.Equal(t, 5, st.Tick)
+
+	var board snake.Board
+	require.Equal(t, http.StatusOK, do(t, s, "GET", "/games/1/board", nil, &board))
+	require.Equal(t, st.Board, board)
+	var stats []PlayerStats
+	require.Equal(t, http.StatusOK, do(t, s, "GET", "/games/1/players", nil, &stats))
+	require.Equal(t, st.Players, stats)
+	var one PlayerStats
+	require.Equal(t, http.StatusOK, do(t, s, "GET", "/games/1/players/3", nil, &one))
+	require.Equal(t, st.Players[1], one)
+
+	require.Equal(t, http.StatusNoContent, do(t, s, "DELETE", "/games/1", nil, nil))
+	require.Equal(t, http.StatusNotFound, do(t, s, "GET", "/games/1", nil, nil))
+}
+
+func TestServerErrors(t *testing.T) {
+	s := &Server{}
+	var e map[string]string
+	require.Equal(t, http.StatusBadRequest, do(t, s, "POST", "/games", GameRequest{Height: 15, Width: 15, Players: []string{"nobody"}}, &e))
+	require.NotEmpty(t, e["error"])
+	require.Equal(t, http.StatusBadRequest, do(t, s, "POST", "/games", GameRequest{Height: 3, Width: 3, Players: []string{"random"}}, nil))
+
+	require.Equal(t, http.StatusCreated, do(t, s, "POST", "/games", GameRequest{Height: 10, Width: 10, Players: []string{External, "random"}}, nil))
+	require.Equal(t, http.StatusBadRequest, do(t, s, "POST", "/games/1/moves", map[string]string{"3": "l"}, nil))
+	require.Equal(t, http.StatusBadRequest, do(t, s, "POST", "/games/1/moves", map[string]string{"2": "up"}, nil))
+	require.Equal(t, http.StatusBadRequest, do(t, s, "POST", "/games/1/tick?n=0", nil, nil))
+	require.Equal(t, http.StatusNotFound, do(t, s, "GET", "/games/1/players/4", nil, nil))
+	require.Equal(t, http.StatusNotFound, do(t, s, "POST", "/games/2/tick", nil, nil))
+	require.Equal(t, http.StatusNotFound, do(t, s, "DELETE", "/games/2", nil, nil))
+}
+
+func TestLimits(t *testing.T) {
+	s := &Server{MaxSide: 20, MaxPlayers: 2, MaxTicks: 5, MaxGames: 2}
+	require.Equal(t, http.StatusBadRequest, do(t, s, "POST", "/games", GameRequest{Height: 21, Width: 10, Players: []string{"random"}}, nil))
+	require.Equal(t, http.StatusBadRequest, do(t, s, "POST", "/games", GameRequest{Height: 10, Width: 100000, Players: []string{"random"}}, nil))
+	require.Equal(t, http.StatusBadRequest, do(t, s, "POST", "/games", GameRequest{Height: 10, Width: 10, Players: []string{"random", "random", "random"}}, nil))
+
+	require.Equal(t, http.StatusCreated, do(t, s, "POST", "/games", GameRequest{Height: 20, Width: 20, Players: []string{"random", "random"}}, nil))
+	require.Equal(t, http.StatusBadRequest, do(t, s, "POST", "/games/1/tick?n=6", nil, nil))
+	require.Equal(t, http.StatusOK, do(t, s, "POST", "/games/1/tick?n=5", nil, nil))
+
+	// a full server takes games again once one is deleted
+	require.Equal(t, http.StatusCreated, do(t, s, "POST", "/games", GameRequest{Height: 10, Width: 10, Players: []string{"random"}}, nil))
+	require.Equal(t, http.StatusServiceUnavailable, do(t, s, "POST", "/games", GameRequest{Height: 10, Width: 10, Players: []string{"random"}}, nil))
+	require.Equal(t, http.StatusNoContent, do(t, s, "DELETE", "/games/1", nil, nil))
+	require.Equal(t, http.StatusCreated, do(t, s, "POST", "/games", GameRequest{Height: 10, Width: 10, Players: []string{"random"}}, nil))
+
+	// the defaults hold without limits
+	s = &Server{}
+	require.Equal(t, http.StatusBadRequest, do(t, s, "POST", "/games", GameRequest{Height: DefaultMaxSide + 1, Width: 10, Players: []string{"random"}}, nil))
+	require.Equal(t, http.StatusCreated, do(t, s, "POST", "/games", GameRequest{Height: 10, Width: 10, Players: []string{"random"}}, nil))
+	require.Equal(t, http.StatusBadRequest, do(t, s, "POST", fmt.Sprintf("/games/1/tick?n=%d", DefaultMaxTicks+1), nil, nil))
+}
+
+func TestRemoveCloses(t *testing.T) {
+	p := &closer{}
+	s := &Server{NewPlayer: func(string) (snake.Player, error) { return p, nil }}
+	require.Equal(t, http.StatusCreated, do(t, s, "POST", "/games", GameRequest{Height: 10, Width: 10, Players: []string{"closer"}}, nil))
+	require.False(t, p.closed)
+	require.Equal(t, http.StatusNoContent, do(t, s, "DELETE", "/games/1", nil, nil))
+	require.True(t, p.closed)
+
+	// the players of a game that is refused are closed too
+	p.closed = false
+	require.Equal(t, http.StatusBadRequest, do(t, s, "POST", "/games", GameRequest{Height: 3, Width: 3, Players: []string{"closer"}}, nil))
+	require.True(t, p.closed)
+}
+
+func TestGameOver(t *testing.T) {
+	s := &Server{}
+	require.Equal(t, http.StatusCreated, do(t, s, "POST", "/games", GameRequest{Height: 6, Width: 6, Players: []string{External}}, nil))
+
+	// going straight ends in a wall
+	var st State
+	require.Equal(t, http.StatusOK, do(t, s, "POST", "/games/1/tick?n=10", nil, &st))
+	require.True(t, st.GameOver)
+	require.False(t, st.Players[0].Alive)
+	require.Equal(t, "wall", st.Players[0].Cause)
+	tick := st.Tick
+	require.Equal(t, http.StatusOK, do(t, s, "POST", "/games/1/tick", nil, &st))
+	require.Equal(t, tick, st.Tick)
+}
+
+// turner plays the moves in order
+type turner struct {
+	id    snake.ID
+	moves []string
+	tick  int
+}
+
+func (p *turner) Play(snake.GameState) snake.Move {
+	m := snake.Move{Move: []float64{0, 1, 0}, ID: p.id}
+	if p.tick < len(p.moves) {
+		switch p.moves[p.tick] {
+		case "l":
+			m.Move = []float64{1, 0, 0}
+		case "r":
+			m.Move = []float64{0, 0, 1}
+		}
+	}
+	p.tick++
+	return m
+}
+
+func (p *turner) SetID(id snake.ID) { p.id = id }
+
+// closer is a player that records being closed
+type closer struct {
+	snake.Random
+	closed bool
+}
+
+func (c *closer) Close() error {
+	c.closed = true
+	return nil
+}
@@ -0,0 +1,79 @@
+// Package bots holds hand written snakes, they are baselines for the trained networks
+package bots
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/wouterbeets/snake"
+)
+
+var constructors = map[string]func() snake.Player{
+	"greedy":     func() snake.Player { return &Greedy{} },
+	"hamilton":   func() snake.Player { return &Hamilton{} },
+	"pathfinder": func() snake.Player { return &Pathfinder{} },
+	"survivor":   func() snake.Player { return &Survivor{} },
+}
+
+// New returns a new bot by its name
+func New(name string) (snake.Player, error) {
+	c, ok := constructors[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown bot %q", name)
+	}
+	return c(), nil
+}
+
+// Names returns the names New accepts
+func Names() []string {
+	names := make([]string, 0, len(constructors))
+	for name := range constructors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// turn is a move relative to the heading of the snake
+type turn int
+
+const (
+	left turn = iota
+	straight
+	right
+)
+
+var turns = []turn{straight, left, right}
+
+func (t turn) move(id snake.ID) snake.Move {
+	m := snake.Move{Move: make([]float64, 3), ID: id}
+	m.Move[t] = 1
+	return m
+}
+
+// next returns the cell the snake moves into when it takes turn t
+func next(s snake.SnakeInfo, t turn) snake.Position {
+	fx, fy := s.Heading.Vector()
+	switch t {
+	case left:
+		fx, fy = fy, -fx
+	case right:
+		fx, fy = -fy, fx
+	}
+	return snake.NewPosition(s.Head.X()+fx, s.Head.Y()+fy)
+}
+
+// turnTo returns the turn that moves the snake into the neighbouring cell p
+func turnTo(s snake.SnakeInfo, p snake.Position) (turn, bool) {
+	for _, t := range turns {
+		if next(s, t) == p {
+			return t, true
+		}
+	}
+	return straight, false
+}
+
+// free reports if a snake can move into the cell
+func free(b snake.Board, p snake.Position) bool {
+	return b.At(p.Y(), p.X()) <= 0
+}
@@ -0,0 +1,98 @@
+package bots
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wouterbeets/snake"
+)
+
+// parseBoard reads a board drawn with # for walls, F for food, . for empty cells
+// and digits for snakes
+func parseBoard(rows ...string) snake.Board {
+	b := make(snake.Board, len(rows))
+	for y, row := range rows {
+		b[y] = make([]int8, len(row))
+		for x, c := range row {
+			switch {
+			case c == '#':
+				b[y][x] = 1
+			case c == 'F':
+				b[y][x] = -1
+			case c >= '2' && c <= '9':
+				b[y][x] = int8(c - '0')
+			}
+		}
+	}
+	return b
+}
+
+func foodOn(b snake.Board) (food []snake.Position) {
+	for y := range b {
+		for x := range b[y] {
+			if b[y][x] < 0 {
+				food = append(food, snake.NewPosition(x, y))
+			}
+		}
+	}
+	return
+}
+
+func TestNext(t *testing.T) {
+	s := snake.SnakeInfo{Head: snake.NewPosition(5, 5), Heading: snake.North}
+	require.Equal(t, snake.NewPosition(5, 4), next(s, straight))
+	require.Equal(t, snake.NewPosition(4, 5), next(s, left))
+	require.Equal(t, snake.NewPosition(6, 5), next(s, right))
+
+	s.Heading = snake.East
+	require.Equal(t, snake.NewPosition(6, 5), next(s, straight))
+	require.Equal(t, snake.NewPosition(5, 4), next(s, left))
+	require.Equal(t, snake.NewPosition(5, 6), next(s, right))
+
+	tr, ok := turnTo(s, snake.NewPosition(5, 6))
+	require.True(t, ok)
+	require.Equal(t, right, tr)
+	_, ok = turnTo(s, snake.NewPosition(4, 5))
+	require.False(t, ok)
+
+	require.Equal(t, []float64{0, 0, 1}, right.move(2).Move)
+}
+
+func TestNew(t *testing.T) {
+	for _, name := range Names() {
+		p, err := New(name)
+		require.NoError(t, err)
+		require.NotNil(t, p)
+	}
+	_, err := New("nobody")
+	require.Error(t, err)
+	require.Equal(t, "greedy,hamilton,pathfinder,survivor", strings.Join(Names(), ","))
+}
+
+func TestBotsEat(t *testing.T) {
+	for _, name := range Names() {
+		p, err := New(name)
+		require.NoError(t, err)
+		g, err := snake.NewGameWithConfig(snake.GameConfig{
+			Height:      15,
+			Width:       15,
+			Players:     []snake.Player{p},
+			NbFoodOnMap: 3,
+			Seed:        1,
+		})
+		require.NoError(t, err)
+		maxLen := 0
+		g.Subscribe(func(e snake.Event) {
+			if e.Type == snake.AteFood && g.PlayerLen(e.ID) > maxLen {
+				maxLen = g.PlayerLen(e.ID)
+			}
+		})
+		for i := 0; i < 100; i++ {
+			if gameOver, _ := g.PlayRound(); gameOver {
+				break
+			}
+		}
+		require.True(t, maxLen > 2, name)
+	}
+}
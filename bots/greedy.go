@@ -0,0 +1,44 @@
+package bots
+
+import "github.com/wouterbeets/snake"
+
+// Greedy moves into the free neighbouring cell closest to the nearest food.
+// It never plans ahead so it happily walks into dead ends
+type Greedy struct {
+	ID snake.ID
+}
+
+// Play implements snake.Player
+func (g *Greedy) Play(gs snake.GameState) snake.Move {
+	self, ok := gs.Snake(g.ID)
+	if !ok {
+		return straight.move(g.ID)
+	}
+	return greedy(self, gs.Board(), gs.Food()).move(g.ID)
+}
+
+// SetID implements snake.Player
+func (g *Greedy) SetID(id snake.ID) {
+	g.ID = id
+}
+
+// greedy returns the free turn closest to any food, straight wins ties
+func greedy(self snake.SnakeInfo, b snake.Board, food []snake.Position) turn {
+	best, bestDist := straight, -1
+	for _, t := range turns {
+		p := next(self, t)
+		if !free(b, p) {
+			continue
+		}
+		d := len(b) + len(b[0])
+		for _, f := range food {
+			if fd := p.Distance(f); fd < d {
+				d = fd
+			}
+		}
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = t, d
+		}
+	}
+	return best
+}
@@ -0,0 +1,32 @@
+package bots
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wouterbeets/snake"
+)
+
+func TestGreedy(t *testing.T) {
+	b := parseBoard(
+		"#######",
+		"#.....#",
+		"#.2...#",
+		"#.2..F#",
+		"#######",
+	)
+	self := snake.SnakeInfo{Head: snake.NewPosition(2, 2), Heading: snake.North}
+	require.Equal(t, right, greedy(self, b, foodOn(b)))
+
+	// straight is a wall and left and right are as far from the food behind it,
+	// the first free move in left, straight, right order wins the tie
+	b = parseBoard(
+		"#######",
+		"#..2..#",
+		"#..2..#",
+		"#######",
+		"#..F..#",
+	)
+	self = snake.SnakeInfo{Head: snake.NewPosition(3, 1), Heading: snake.North}
+	require.Equal(t, left, greedy(self, b, foodOn(b)))
+}
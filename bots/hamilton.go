@@ -0,0 +1,135 @@
+package bots
+
+import "github.com/wouterbeets/snake"
+
+// Hamilton follows a cycle through every cell inside the walls, so on a board
+// of its own it never dies and fills the board. While it is shorter than half
+// the board it cuts the cycle short towards food as long as that can not make
+// it run into its own tail. The cycle only exists when the height or width
+// inside the walls is even, on other boards it plays like Survivor
+type Hamilton struct {
+	ID snake.ID
+
+	// order is the position of every cell on the cycle, -1 outside of it
+	order [][]int
+	cells int
+	// dir is the direction the cycle is followed in, 1 or -1
+	dir int
+}
+
+// Play implements snake.Player
+func (h *Hamilton) Play(gs snake.GameState) snake.Move {
+	self, ok := gs.Snake(h.ID)
+	if !ok {
+		return straight.move(h.ID)
+	}
+	if h.order == nil || len(h.order) != gs.Height() || len(h.order[0]) != gs.Width() {
+		h.order, h.cells = cycle(gs.Height(), gs.Width())
+	}
+	if h.cells == 0 {
+		return (&Survivor{ID: h.ID}).Play(gs)
+	}
+
+	head := h.index(self.Head)
+	tail := h.index(self.Body[0])
+	// a new snake goes around the cycle in the direction that has its tail behind it
+	if h.dir == 0 || self.Length <= 2 {
+		h.dir = 1
+		if h.ahead(head, tail, -1) > h.ahead(head, tail, 1) {
+			h.dir = -1
+		}
+	}
+	dir := h.dir
+	room := h.ahead(head, tail, dir)
+
+	target := h.cells
+	for _, f := range gs.Food() {
+		if a := h.ahead(head, h.index(f), dir); a < target {
+			target = a
+		}
+	}
+	shortcut := 2*self.Length < h.cells
+
+	b := gs.Board()
+	best, bestAhead := straight, 0
+	for _, t := range turns {
+		p := next(self, t)
+		if !free(b, p) {
+			continue
+		}
+		a := h.ahead(head, h.index(p), dir)
+		switch {
+		case a == 1:
+		case !shortcut || a > target || room-a <= len(gs.Food())+2:
+			continue
+		}
+		if a > bestAhead {
+			best, bestAhead = t, a
+		}
+	}
+	if bestAhead == 0 {
+		return (&Survivor{ID: h.ID}).Play(gs)
+	}
+	return best.move(h.ID)
+}
+
+// SetID implements snake.Player
+func (h *Hamilton) SetID(id snake.ID) {
+	h.ID = id
+	h.dir = 0
+}
+
+func (h *Hamilton) index(p snake.Position) int {
+	return h.order[p.Y()][p.X()]
+}
+
+// ahead returns the number of steps from cell index from to cell index to
+// going around the cycle in direction dir
+func (h *Hamilton) ahead(from, to, dir int) int {
+	return ((to-from)*dir%h.cells + h.cells) % h.cells
+}
+
+// cycle numbers the cells inside the walls of a height by width board in the
+// order of a hamiltonian cycle. Rows are walked back and forth leaving out the
+// first column, the first column leads back up to the start. The board is
+// walked column by column when only the width inside the walls is even.
+// cells is 0 when there is no cycle
+func cycle(height, width int) (order [][]int, cells int) {
+	h, w := height-2, width-2
+	order = make([][]int, height)
+	for y := range order {
+		order[y] = make([]int, width)
+		for x := range order[y] {
+			order[y][x] = -1
+		}
+	}
+	if h < 2 || w < 2 || h%2 == 1 && w%2 == 1 {
+		return order, 0
+	}
+
+	set := func(r, c int) {
+		if h%2 == 0 {
+			order[r+1][c+1] = cells
+		} else {
+			order[c+1][r+1] = cells
+		}
+		cells++
+	}
+	rows, cols := h, w
+	if h%2 == 1 {
+		rows, cols = w, h
+	}
+	for r := 0; r < rows; r++ {
+		for c := 1; c < cols; c++ {
+			if r%2 == 0 {
+				set(r, c)
+			} else {
+				set(r, cols-c)
+			}
+		}
+	}
+	for r := rows - 1; r >= 0; r-- {
+		set(r, 0)
+	}
+	return order, cells
+}
@@ -0,0 +1,57 @@
+package bots
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wouterbeets/snake"
+)
+
+func TestCycle(t *testing.T) {
+	for _, size := range [][2]int{{4, 4}, {6, 6}, {7, 6}, {6, 7}, {8, 11}} {
+		order, cells := cycle(size[0], size[1])
+		require.Equal(t, (size[0]-2)*(size[1]-2), cells, size)
+
+		// every cell is on the cycle once and the next cell is always a neighbour
+		pos := make([]snake.Position, cells)
+		seen := make(map[int]bool)
+		for y := 1; y < size[0]-1; y++ {
+			for x := 1; x < size[1]-1; x++ {
+				i := order[y][x]
+				require.False(t, seen[i], size)
+				seen[i] = true
+				pos[i] = snake.NewPosition(x, y)
+			}
+		}
+		for i := range pos {
+			require.Equal(t, 1, pos[i].Distance(pos[(i+1)%cells]), size)
+		}
+	}
+
+	_, cells := cycle(7, 7)
+	require.Equal(t, 0, cells)
+}
+
+func TestHamiltonFillsBoard(t *testing.T) {
+	for _, size := range [][2]int{{6, 6}, {7, 6}, {10, 10}} {
+		for seed := int64(0); seed < 5; seed++ {
+			h := &Hamilton{}
+			g, err := snake.NewGameWithConfig(snake.GameConfig{
+				Height:      size[0],
+				Width:       size[1],
+				Players:     []snake.Player{h},
+				NbFoodOnMap: 1,
+				Seed:        seed,
+			})
+			require.NoError(t, err)
+
+			cells := (size[0] - 2) * (size[1] - 2)
+			r, err := g.Run(context.Background(), snake.RunOptions{MaxTicks: 100 * cells})
+			require.NoError(t, err)
+			require.True(t, r.Full, "size %v seed %d", size, seed)
+			require.Equal(t, []snake.ID{h.ID}, r.Alive)
+			require.Equal(t, cells, g.PlayerLen(h.ID))
+		}
+	}
+}
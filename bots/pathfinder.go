@@ -0,0 +1,68 @@
+package bots
+
+import "github.com/wouterbeets/snake"
+
+// Pathfinder follows the shortest path to the nearest reachable food, the path avoids
+// walls and bodies. When no food can be reached it plays like Greedy
+type Pathfinder struct {
+	ID snake.ID
+}
+
+// Play implements snake.Player
+func (p *Pathfinder) Play(gs snake.GameState) snake.Move {
+	self, ok := gs.Snake(p.ID)
+	if !ok {
+		return straight.move(p.ID)
+	}
+	b := gs.Board()
+	if step, ok := firstStep(self, b); ok {
+		if t, ok := turnTo(self, step); ok {
+			return t.move(p.ID)
+		}
+	}
+	return greedy(self, b, gs.Food()).move(p.ID)
+}
+
+// SetID implements snake.Player
+func (p *Pathfinder) SetID(id snake.ID) {
+	p.ID = id
+}
+
+// firstStep searches breadth first from the head to the nearest food
+// and returns the first cell of the path to it
+func firstStep(self snake.SnakeInfo, b snake.Board) (snake.Position, bool) {
+	// first holds the first step of the path to every visited cell
+	first := make(map[snake.Position]snake.Position)
+	var queue []snake.Position
+	for _, t := range turns {
+		p := next(self, t)
+		if free(b, p) {
+			first[p] = p
+			queue = append(queue, p)
+		}
+	}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if b.At(p.Y(), p.X()) < 0 {
+			return first[p], true
+		}
+		for _, n := range neighbours(p) {
+			if _, seen := first[n]; seen || !free(b, n) || n == self.Head {
+				continue
+			}
+			first[n] = first[p]
+			queue = append(queue, n)
+		}
+	}
+	return snake.Position{}, false
+}
+
+func neighbours(p snake.Position) []snake.Position {
+	return []snake.Position{
+		snake.NewPosition(p.X(), p.Y()-1),
+		snake.NewPosition(p.X()+1, p.Y()),
+		snake.NewPosition(p.X(), p.Y()+1),
+		snake.NewPosition(p.X()-1, p.Y()),
+	}
+}
@@ -0,0 +1,39 @@
+package bots
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wouterbeets/snake"
+)
+
+func TestFirstStep(t *testing.T) {
+	// greedy would turn right into the pocket, the path goes around the wall
+	b := parseBoard(
+		"#########",
+		"#.......#",
+		"#...##..#",
+		"#..2.#F.#",
+		"#..2##..#",
+		"#########",
+	)
+	self := snake.SnakeInfo{Head: snake.NewPosition(3, 3), Heading: snake.North}
+	require.Equal(t, right, greedy(self, b, foodOn(b)))
+	step, ok := firstStep(self, b)
+	require.True(t, ok)
+	tr, ok := turnTo(self, step)
+	require.True(t, ok)
+	require.Equal(t, straight, tr)
+
+	// no food can be reached
+	b = parseBoard(
+		"#######",
+		"#..2..#",
+		"#..2..#",
+		"#######",
+		"#..F..#",
+	)
+	self = snake.SnakeInfo{Head: snake.NewPosition(3, 1), Heading: snake.North}
+	_, ok = firstStep(self, b)
+	require.False(t, ok)
+}
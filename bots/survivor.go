@@ -0,0 +1,101 @@
+package bots
+
+import "github.com/wouterbeets/snake"
+
+// Survivor flood fills the board before every move and only goes for food
+// when the move leaves it enough room for its body, otherwise it takes the
+// move with the most room. It stays out of the pockets Greedy walks into
+type Survivor struct {
+	ID snake.ID
+}
+
+// Play implements snake.Player
+func (s *Survivor) Play(gs snake.GameState) snake.Move {
+	self, ok := gs.Snake(s.ID)
+	if !ok {
+		return straight.move(s.ID)
+	}
+	b := gs.Board()
+	safety := Safety(gs, s.ID)
+	safe := func(t turn) bool {
+		return safety[t] > self.Length
+	}
+
+	if step, ok := firstStep(self, b); ok {
+		if t, ok := turnTo(self, step); ok && safe(t) {
+			return t.move(s.ID)
+		}
+	}
+	if t := greedy(self, b, gs.Food()); safe(t) {
+		return t.move(s.ID)
+	}
+	best := straight
+	for _, t := range turns {
+		if safety[t] > safety[best] {
+			best = t
+		}
+	}
+	return best.move(s.ID)
+}
+
+// SetID implements snake.Player
+func (s *Survivor) SetID(id snake.ID) {
+	s.ID = id
+}
+
+// Safety returns for the left, straight and right move of player id the number of cells
+// its head can still reach after making the move, 0 means the move is deadly.
+// Bodies count as walls until their tails moved out of the way.
+// The order is the one of snake.Move so any player can use it as a diagnostic
+func Safety(gs snake.GameState, id snake.ID) []int {
+	safety := make([]int, len(turns))
+	self, ok := gs.Snake(id)
+	if !ok {
+		return safety
+	}
+	b := gs.Board()
+	vacate := vacated(append(gs.Opponents(id), self))
+	for _, t := range turns {
+		if p := next(self, t); free(b, p) {
+			safety[t] = space(b, vacate, p)
+		}
+	}
+	return safety
+}
+
+// vacated returns for every body cell the step from which a head can move into it,
+// the tail is out of the way after one step but the cell is only free the step after
+func vacated(snakes []snake.SnakeInfo) map[snake.Position]int {
+	vacate := make(map[snake.Position]int)
+	for _, s := range snakes {
+		for i, p := range s.Body {
+			vacate[p] = i + 2
+		}
+	}
+	return vacate
+}
+
+// space counts the cells reachable from start, start is entered on the first step
+func space(b snake.Board, vacate map[snake.Position]int, start snake.Position) int {
+	depth := map[snake.Position]int{start: 1}
+	queue := []snake.Position{start}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, n := range neighbours(p) {
+			if _, seen := depth[n]; seen {
+				continue
+			}
+			if step, body := vacate[n]; body {
+				if step > depth[p]+1 {
+					continue
+				}
+			} else if !free(b, n) {
+				continue
+			}
+			depth[n] = depth[p] + 1
+			queue = append(queue, n)
+		}
+	}
+	return len(depth)
+}
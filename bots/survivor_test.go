@@ -0,0 +1,85 @@
+package bots
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wouterbeets/snake"
+)
+
+// boardState is a game state made from a drawn board, only the methods the bots use work
+type boardState struct {
+	snake.GameState
+	board  snake.Board
+	snakes []snake.SnakeInfo
+}
+
+func (s boardState) Board() snake.Board { return s.board }
+
+func (s boardState) Food() []snake.Position { return foodOn(s.board) }
+
+func (s boardState) Snake(id snake.ID) (snake.SnakeInfo, bool) {
+	for _, sn := range s.snakes {
+		if sn.ID == id {
+			return sn, true
+		}
+	}
+	return snake.SnakeInfo{}, false
+}
+
+func (s boardState) Opponents(id snake.ID) []snake.SnakeInfo {
+	var opponents []snake.SnakeInfo
+	for _, sn := range s.snakes {
+		if sn.ID != id {
+			opponents = append(opponents, sn)
+		}
+	}
+	return opponents
+}
+
+// info describes snake id with its body given tail first
+func info(id snake.ID, h snake.Heading, body ...snake.Position) snake.SnakeInfo {
+	return snake.SnakeInfo{ID: id, Head: body[len(body)-1], Body: body, Heading: h, Length: len(body)}
+}
+
+func TestSafety(t *testing.T) {
+	// only 5 cells are free now, the whole box is once the tail moves away
+	gs := boardState{
+		board: parseBoard(
+			"#####",
+			"#...#",
+			"#..2#",
+			"#222#",
+			"#####",
+		),
+		snakes: []snake.SnakeInfo{info(2, snake.North,
+			snake.NewPosition(1, 3), snake.NewPosition(2, 3), snake.NewPosition(3, 3), snake.NewPosition(3, 2),
+		)},
+	}
+	require.Equal(t, []int{9, 9, 0}, Safety(gs, 2))
+	require.Equal(t, []int{0, 0, 0}, Safety(gs, 3))
+}
+
+func TestSurvivorAvoidsPocket(t *testing.T) {
+	// the food to the right is in a pocket too small for the snake
+	gs := boardState{
+		board: parseBoard(
+			"#########",
+			"#...#####",
+			"#..2F.###",
+			"#..2##..#",
+			"#..2....#",
+			"#.......#",
+			"#########",
+		),
+		snakes: []snake.SnakeInfo{info(2, snake.North,
+			snake.NewPosition(3, 4), snake.NewPosition(3, 3), snake.NewPosition(3, 2),
+		)},
+	}
+	require.Equal(t, 2, Safety(gs, 2)[right])
+
+	p := &Pathfinder{ID: 2}
+	require.Equal(t, right.move(2), p.Play(gs))
+	s := &Survivor{ID: 2}
+	require.Equal(t, straight.move(2), s.Play(gs))
+}
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"log"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/klokare/evo"
@@ -17,6 +18,7 @@ import (
 	"github.com/klokare/evo/neat"
 	"github.com/wouterbeets/snake"
 	"github.com/wouterbeets/snake/ai"
+	"github.com/wouterbeets/snake/bots"
 	"github.com/wouterbeets/term"
 )
 
@@ -29,11 +31,26 @@ func main() {
 		epath  = flag.String("efficacy", "xor-samples.txt", "path for efficacy sample file")
 		aiOut  = flag.String("aiout", "ai.json", "path for ai")
 		loadAi = flag.String("loadai", "", "ai in file")
+		record = flag.String("replay", "", "path to save a replay of the game")
+		vname  = flag.String("vision", snake.DefaultVision.Name(), "vision of the snakes, one of "+strings.Join(snake.Visions(), ", "))
+		killRw = flag.Float64("killreward", 0.5, "fitness added for every snake killed")
+		selfPn = flag.Float64("selfpenalty", 0.5, "fitness removed for running into yourself")
+		spar   = flag.String("sparring", "", "comma separated bots joining the training games, one of "+strings.Join(bots.Names(), ", "))
 	)
 	flag.Parse()
 
-	// Load the configuration
-	src, err := source.NewJSONFromFile(*cpath)
+	vision, err := snake.LookupVision(*vname)
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+
+	// Load the configuration with the number of inputs of the vision
+	visionCfg, remove, err := ai.ConfigFile(*cpath, vision)
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+	defer remove()
+	src, err := source.NewJSONFromFile(visionCfg)
 	if err != nil {
 		log.Fatalf("%+v\n", err)
 	}
@@ -82,7 +99,21 @@ func main() {
 	}
 
 	exp := neat.NewExperiment(cfg)
-	exp.Searcher = ai.Trainer{}
+	trainer := ai.Trainer{KillReward: *killRw, SelfPenalty: *selfPn, Vision: vision}
+	for _, name := range strings.Split(*spar, ",") {
+		if name == "" {
+			continue
+		}
+		if _, err := bots.New(name); err != nil {
+			log.Fatalf("%+v\n", err)
+		}
+		name := name
+		trainer.Sparring = append(trainer.Sparring, func() snake.Player {
+			p, _ := bots.New(name)
+			return p
+		})
+	}
+	exp.Searcher = trainer
 	if *loadAi == "" {
 
 		for r := 0; r < *runs; r++ {
@@ -139,7 +170,7 @@ func main() {
 			defer fn() // ensure the context cancels
 			exp.AddSubscription(evo.Subscription{Event: evo.Evaluated, Callback: cb})
 			// Execute the experiment
-			if _, err = evo.Run(ctx, exp, ai.Evaluator{}); err != nil {
+			if _, err = evo.Run(ctx, exp, ai.Evaluator{Vision: vision}); err != nil {
 				log.Fatalf("%+v\n", err)
 			}
 		}
@@ -167,7 +198,7 @@ func main() {
 		if err != nil {
 			panic(err.Error())
 		}
-		nets = append(nets, &ai.NetWrapper{Ai: net})
+		nets = append(nets, &ai.NetWrapper{Ai: net, Vision: vision})
 	}
 
 	framerate := 10 * time.Millisecond
@@ -178,7 +209,22 @@ func main() {
 	for _, n := range nets {
 		players = append(players, n)
 	}
-	g, err := snake.NewGame(50, 50, players, 50)
+	gameCfg := snake.GameConfig{
+		Height:      50,
+		Width:       50,
+		Players:     players,
+		NbFoodOnMap: 50,
+		Seed:        time.Now().UnixNano(),
+	}
+	var replay *os.File
+	if *record != "" {
+		replay, err = os.Create(*record)
+		if err != nil {
+			panic(err.Error())
+		}
+		gameCfg.Recorder = snake.NewRecorder(replay)
+	}
+	g, err := snake.NewGameWithConfig(gameCfg)
 	if err != nil {
 		panic(err)
 	}
@@ -202,27 +248,28 @@ func main() {
 		13: 'd',
 		14: 'e',
 	}
-	for i := 0; i < 10000000; i++ {
-		gameOver, state := g.PlayRound()
-		sc.Input <- stateToRune(state, runes)
-		if gameOver {
-			close(sc.Input)
-			return
-		}
-	}
-	<-done
-}
-
-func stateToRune(state snake.Board, runes map[int8]rune) (disp [][]rune) {
-	disp = make([][]rune, len(state))
-	for i := range disp {
-		disp[i] = make([]rune, len(state[i]))
+	res, err := g.Run(context.Background(), snake.RunOptions{
+		MaxTicks: 10000000,
+		OnTick: func(state snake.Board) {
+			sc.Input <- state.Runes(runes)
+		},
+	})
+	if err != nil {
+		panic(err)
 	}
-
-	for y, row := range state {
-		for x := range row {
-			disp[y][x] = runes[state[y][x]]
+	if replay != nil {
+		// a failed write leaves a replay that stops early
+		err := gameCfg.Recorder.Err()
+		if cerr := replay.Close(); err == nil {
+			err = cerr
 		}
+		if err != nil {
+			log.Fatalf("writing replay: %+v\n", err)
+		}
+	}
+	if res.GameOver {
+		close(sc.Input)
+		return
 	}
-	return disp
+	<-done
 }
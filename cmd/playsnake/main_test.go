@@ -13,7 +13,7 @@ func TestSnakeNewGame(t *testing.T) {
 		g, err := snake.NewGame(100, 100, []snake.Player{
 			&snake.Random{},
 			&snake.Random{},
-		})
+		}, 1)
 		require.NoError(t, err)
 		fmt.Println(i, "==============================")
 		for i := 0; i < 100; i++ {
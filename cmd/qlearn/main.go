@@ -0,0 +1,106 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/wouterbeets/snake"
+	"github.com/wouterbeets/snake/ai"
+	"github.com/wouterbeets/snake/env"
+	"github.com/wouterbeets/snake/qlearn"
+)
+
+// qlearn trains a q-learning table and saves it, training goes on from the table when it exists.
+// The table plays in tournaments as q:path
+func main() {
+	var (
+		out        = flag.String("table", "q.json", "path of the table, it is loaded when it exists and saved after training")
+		episodes   = flag.Int("episodes", 10000, "number of episodes to train")
+		opponents  = flag.String("opponents", "", "opponents of the snake, "+ai.PlayerUsage)
+		cpath      = flag.String("config", "genn.json", "path to the configuration file used to load the networks")
+		height     = flag.Int("height", 20, "height of the board")
+		width      = flag.Int("width", 20, "width of the board")
+		food       = flag.Int("food", 5, "food on the board")
+		ticks      = flag.Int("ticks", 1000, "maximum number of ticks per episode")
+		alpha      = flag.Float64("alpha", 0.1, "learning rate")
+		gamma      = flag.Float64("gamma", 0.9, "discount of the next state")
+		epsilon    = flag.Float64("epsilon", 0.5, "exploration of the first episode")
+		decay      = flag.Float64("decay", 0.999, "exploration is multiplied by decay after every episode")
+		minEpsilon = flag.Float64("min-epsilon", 0.01, "lowest exploration")
+		seed       = flag.Int64("seed", 1, "seed of the first episode, episode i uses seed+i")
+		every      = flag.Int("every", 500, "episodes between two progress lines")
+	)
+	flag.Parse()
+	if *every < 1 {
+		log.Fatalf("every must be at least 1, got %d\n", *every)
+	}
+
+	// the episodes are played one after the other, so exec opponents keep one process
+	opps, err := parseOpponents(*opponents, ai.SpecOptions{LoadNetworks: ai.NetworkLoader(*cpath), Stderr: os.Stderr})
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+	t, err := qlearn.Load(*out)
+	if os.IsNotExist(err) {
+		t, err = make(qlearn.Table), nil
+	}
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+
+	var total float64
+	var length int
+	tr := qlearn.Trainer{
+		Env: env.Config{
+			Height:    *height,
+			Width:     *width,
+			Food:      *food,
+			Opponents: opps,
+			MaxTicks:  *ticks,
+		},
+		Alpha:      *alpha,
+		Gamma:      *gamma,
+		Epsilon:    *epsilon,
+		Decay:      *decay,
+		MinEpsilon: *minEpsilon,
+		Seed:       *seed,
+		OnEpisode: func(i int, reward float64, info env.Info) {
+			total += reward
+			length += info.Length
+			if (i+1)%*every == 0 {
+				n := float64(*every)
+				fmt.Printf("episode %d: reward %.2f length %.2f states %d\n", i+1, total/n, float64(length)/n, len(t))
+				total, length = 0, 0
+			}
+		},
+	}
+	err = tr.Train(t, *episodes)
+	// the shared processes of exec opponents are stopped
+	for _, o := range opps {
+		if c, ok := o().(io.Closer); ok {
+			c.Close()
+		}
+	}
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+	if err := t.Save(*out); err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+}
+
+// parseOpponents reads the opponents flag
+func parseOpponents(spec string, opts ai.SpecOptions) ([]func() snake.Player, error) {
+	specs, err := ai.ParsePlayers(spec, opts)
+	if err != nil {
+		return nil, err
+	}
+	opps := make([]func() snake.Player, len(specs))
+	for i, s := range specs {
+		opps[i] = s.New
+	}
+	return opps, nil
+}
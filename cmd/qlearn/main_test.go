@@ -0,0 +1,24 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wouterbeets/snake/ai"
+)
+
+func TestParseOpponents(t *testing.T) {
+	opps, err := parseOpponents("greedy, random,survivor", ai.SpecOptions{})
+	require.NoError(t, err)
+	require.Len(t, opps, 3)
+	for _, o := range opps {
+		require.NotNil(t, o())
+	}
+	opps, err = parseOpponents("", ai.SpecOptions{})
+	require.NoError(t, err)
+	require.Empty(t, opps)
+	_, err = parseOpponents("ai.json", ai.SpecOptions{})
+	require.Error(t, err)
+	_, err = parseOpponents("remote", ai.SpecOptions{})
+	require.Error(t, err)
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/wouterbeets/snake"
+	"github.com/wouterbeets/term"
+)
+
+// keys:
+//
+//	space  pause / resume
+//	d      step one tick forward
+//	a      step one tick back
+//	w      play faster
+//	s      play slower
+//	0-9 g  type a tick number and jump to it with g
+//	q      quit
+func main() {
+	var (
+		in     = flag.String("replay", "replay.json", "path of the replay to watch")
+		frames = flag.String("frames", "", "path to save the boards of every tick to")
+		speed  = flag.Duration("speed", 100*time.Millisecond, "time between two ticks")
+		start  = flag.Int("start", 0, "tick to start at")
+	)
+	flag.Parse()
+
+	file, err := os.Open(*in)
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+	replay, err := snake.LoadReplay(file)
+	file.Close()
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+	boards, err := capture(replay)
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+	if *frames != "" {
+		out, err := os.Create(*frames)
+		if err != nil {
+			log.Fatalf("%+v\n", err)
+		}
+		if err := json.NewEncoder(out).Encode(boards); err != nil {
+			log.Fatalf("%+v\n", err)
+		}
+		out.Close()
+	}
+
+	framerate := 10 * time.Millisecond
+	sc := term.Screen{Input: make(chan [][]rune), UserInput: make(chan rune)}
+	done := sc.Run(framerate)
+
+	runes := map[int8]rune{
+		-1: 'M',
+		0:  ' ',
+		1:  '█',
+		2:  '2',
+		3:  '3',
+		4:  '4',
+		5:  '5',
+		6:  '6',
+		7:  '7',
+		8:  '8',
+		9:  '9',
+		10: 'a',
+		11: 'b',
+		12: 'c',
+		13: 'd',
+		14: 'e',
+	}
+	v := newViewer(boards, *speed)
+	v.seek(*start)
+	for {
+		disp := v.board().Runes(runes)
+		disp = append(disp, []rune(v.status()))
+		sc.Input <- disp
+		select {
+		case key := <-sc.UserInput:
+			if quit := v.handle(key); quit {
+				close(sc.Input)
+				<-done
+				return
+			}
+		case <-time.After(v.delay):
+			if !v.paused {
+				v.step(1)
+			}
+		}
+	}
+}
+
+// capture plays the replay and returns the board before the first tick and after every tick
+func capture(replay *snake.Replay) ([]snake.Board, error) {
+	g, err := replay.Game()
+	if err != nil {
+		return nil, err
+	}
+	boards := []snake.Board{g.Board()}
+	for range replay.Ticks {
+		g.PlayRound()
+		boards = append(boards, g.Board())
+	}
+	return boards, nil
+}
+
+type viewer struct {
+	boards []snake.Board
+	tick   int
+	paused bool
+	delay  time.Duration
+	input  int
+}
+
+func newViewer(boards []snake.Board, delay time.Duration) *viewer {
+	return &viewer{boards: boards, delay: delay}
+}
+
+func (v *viewer) board() snake.Board {
+	return v.boards[v.tick]
+}
+
+func (v *viewer) status() string {
+	state := "playing"
+	if v.paused {
+		state = "paused"
+	}
+	return fmt.Sprintf("tick %d/%d %s %v", v.tick, len(v.boards)-1, state, v.delay)
+}
+
+// seek jumps to a tick, ticks out of the replay are clamped to the first or last one
+func (v *viewer) seek(tick int) {
+	if tick < 0 {
+		tick = 0
+	}
+	if tick >= len(v.boards) {
+		tick = len(v.boards) - 1
+	}
+	v.tick = tick
+}
+
+// step moves n ticks, the replay pauses when it reaches the end
+func (v *viewer) step(n int) {
+	v.seek(v.tick + n)
+	if v.tick == len(v.boards)-1 {
+		v.paused = true
+	}
+}
+
+// handle applies a key press and reports if the viewer should quit
+func (v *viewer) handle(key rune) (quit bool) {
+	switch {
+	case key >= '0' && key <= '9':
+		v.input = v.input*10 + int(key-'0')
+		return false
+	case key == 'g':
+		v.seek(v.input)
+		v.paused = true
+	case key == ' ':
+		v.paused = !v.paused
+	case key == 'd':
+		v.paused = true
+		v.step(1)
+	case key == 'a':
+		v.paused = true
+		v.step(-1)
+	case key == 'w':
+		if v.delay > time.Millisecond {
+			v.delay /= 2
+		}
+	case key == 's':
+		v.delay *= 2
+	case key == 'q':
+		return true
+	}
+	v.input = 0
+	return false
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wouterbeets/snake"
+)
+
+func TestCapture(t *testing.T) {
+	var buf bytes.Buffer
+	g, err := snake.NewGameWithConfig(snake.GameConfig{
+		Height:      20,
+		Width:       20,
+		Players:     []snake.Player{&snake.Random{}, &snake.Random{}},
+		NbFoodOnMap: 5,
+		Seed:        1,
+		Recorder:    snake.NewRecorder(&buf),
+	})
+	require.NoError(t, err)
+	var boards []snake.Board
+	for i := 0; i < 50; i++ {
+		gameOver, _ := g.PlayRound()
+		boards = append(boards, g.Board())
+		if gameOver {
+			break
+		}
+	}
+
+	replay, err := snake.LoadReplay(&buf)
+	require.NoError(t, err)
+	captured, err := capture(replay)
+	require.NoError(t, err)
+	require.Equal(t, boards, captured[1:])
+}
+
+func TestViewer(t *testing.T) {
+	boards := make([]snake.Board, 10)
+	v := newViewer(boards, 100*time.Millisecond)
+
+	v.handle('d')
+	require.Equal(t, 1, v.tick)
+	require.True(t, v.paused)
+	v.handle('a')
+	v.handle('a')
+	require.Equal(t, 0, v.tick)
+
+	v.handle('7')
+	v.handle('g')
+	require.Equal(t, 7, v.tick)
+	v.handle('4')
+	v.handle('2')
+	v.handle('g')
+	require.Equal(t, 9, v.tick)
+
+	v.handle(' ')
+	require.False(t, v.paused)
+	v.seek(0)
+	for i := 0; i < 20; i++ {
+		v.step(1)
+	}
+	require.Equal(t, 9, v.tick)
+	require.True(t, v.paused)
+
+	v.handle('w')
+	require.Equal(t, 50*time.Millisecond, v.delay)
+	v.handle('s')
+	v.handle('s')
+	require.Equal(t, 200*time.Millisecond, v.delay)
+	require.True(t, v.handle('q'))
+}
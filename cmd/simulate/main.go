@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wouterbeets/snake"
+	"github.com/wouterbeets/snake/ai"
+)
+
+func main() {
+	var (
+		games   = flag.Int("games", 10, "number of games to play")
+		players = flag.String("players", "random,random", ai.PlayerUsage)
+		cpath   = flag.String("config", "genn.json", "path to the configuration file used to load the networks")
+		vname   = flag.String("vision", snake.DefaultVision.Name(), "vision the networks were trained with, one of "+strings.Join(snake.Visions(), ", "))
+		height  = flag.Int("height", 50, "height of the board")
+		width   = flag.Int("width", 50, "width of the board")
+		food    = flag.Int("food", 20, "food on the board")
+		ticks   = flag.Int("ticks", 10000, "maximum number of ticks per game")
+		timeout = flag.Duration("timeout", time.Second, "time a player gets for a move, 0 waits forever")
+		fault   = flag.String("fault", "straight", "what happens to players that time out or panic: straight, forfeit or kill")
+		seed    = flag.Int64("seed", 1, "seed of the first game, game i uses seed+i")
+		resolve = flag.String("resolution", "id", "how moves are resolved: id, headon or longer")
+		format  = flag.String("format", "json", "output format: json or csv")
+		out     = flag.String("out", "", "path of the output, stdout when empty")
+		listen  = flag.String("listen", "localhost:7000", "address remote players connect to, use :7000 to take players from other machines")
+	)
+	flag.Parse()
+
+	resolution, err := parseResolution(*resolve)
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+	faultPolicy, err := parseFaultPolicy(*fault)
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+
+	vision, err := snake.LookupVision(*vname)
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+
+	var l net.Listener
+	accept := func() (snake.Player, error) {
+		if l == nil {
+			if l, err = net.Listen("tcp", *listen); err != nil {
+				return nil, err
+			}
+		}
+		log.Printf("waiting for a remote player on %s\n", l.Addr())
+		p, err := snake.AcceptPlayer(l)
+		if err != nil {
+			return nil, err
+		}
+		p.Timeout = *timeout
+		p.Vision = vision
+		return p, nil
+	}
+	entries, err := ai.ParsePlayers(*players, ai.SpecOptions{
+		LoadNetworks: ai.NetworkLoader(*cpath),
+		Accept:       accept,
+		Vision:       vision,
+		Stderr:       os.Stderr,
+	})
+	if l != nil {
+		l.Close()
+	}
+	if err == nil && len(entries) == 0 {
+		err = errors.New("no players")
+	}
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		file, err := os.Create(*out)
+		if err != nil {
+			log.Fatalf("%+v\n", err)
+		}
+		defer file.Close()
+		w = file
+	}
+	var write func(Result) error
+	switch *format {
+	case "json":
+		enc := json.NewEncoder(w)
+		write = func(r Result) error { return enc.Encode(r) }
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvHeader); err != nil {
+			log.Fatalf("%+v\n", err)
+		}
+		defer cw.Flush()
+		write = func(r Result) error { return writeCSV(cw, r) }
+	default:
+		log.Fatalf("unknown format %q\n", *format)
+	}
+
+	for i := 0; i < *games; i++ {
+		res, err := play(i, snake.GameConfig{
+			Height:      *height,
+			Width:       *width,
+			NbFoodOnMap: *food,
+			Seed:        *seed + int64(i),
+			Resolution:  resolution,
+			MoveTimeout: *timeout,
+			FaultPolicy: faultPolicy,
+		}, entries, *ticks)
+		if err != nil {
+			log.Fatalf("%+v\n", err)
+		}
+		if err := write(res); err != nil {
+			log.Fatalf("%+v\n", err)
+		}
+	}
+}
+
+func parseResolution(s string) (snake.Resolution, error) {
+	switch s {
+	case "id":
+		return snake.ResolveByID, nil
+	case "headon":
+		return snake.ResolveHeadOnKillsBoth, nil
+	case "longer":
+		return snake.ResolveLongerWins, nil
+	}
+	return 0, fmt.Errorf("unknown resolution %q", s)
+}
+
+func parseFaultPolicy(s string) (snake.FaultPolicy, error) {
+	switch s {
+	case "straight":
+		return snake.FaultStraight, nil
+	case "forfeit":
+		return snake.FaultForfeit, nil
+	case "kill":
+		return snake.FaultKill, nil
+	}
+	return 0, fmt.Errorf("unknown fault policy %q", s)
+}
+
+// Result is the outcome of one game
+type Result struct {
+	Game    int            `json:"game"`
+	Seed    int64          `json:"seed"`
+	Ticks   int            `json:"ticks"`
+	Players []PlayerResult `json:"players"`
+}
+
+// PlayerResult is the outcome of one player in a game
+type PlayerResult struct {
+	ID     snake.ID `json:"id"`
+	Player string   `json:"player"`
+	Alive  bool     `json:"alive"`
+	Length int      `json:"length"`
+	Kills  int      `json:"kills"`
+	Faults int      `json:"faults"`
+	// the fields below are only set for dead players
+	Cause     string   `json:"cause,omitempty"`
+	KillerID  snake.ID `json:"killer,omitempty"`
+	DeathTick int      `json:"deathTick,omitempty"`
+}
+
+// play runs one game without a screen until it is over or maxTicks are played
+func play(game int, cfg snake.GameConfig, entries []ai.PlayerSpec, maxTicks int) (Result, error) {
+	for _, e := range entries {
+		cfg.Players = append(cfg.Players, e.New())
+	}
+	g, err := snake.NewGameWithConfig(cfg)
+	if err != nil {
+		return Result{}, err
+	}
+	if _, err := g.Run(context.Background(), snake.RunOptions{MaxTicks: maxTicks}); err != nil {
+		return Result{}, err
+	}
+
+	res := Result{Game: game, Seed: cfg.Seed, Ticks: g.Tick()}
+	for i, e := range entries {
+		id := snake.ID(i + 2)
+		pr := PlayerResult{ID: id, Player: e.Name, Kills: g.Kills(id), Faults: len(g.Faults(id))}
+		if d, ok := g.Death(id); ok {
+			pr.Length = d.Length
+			pr.Cause = d.Cause.String()
+			pr.KillerID = d.KillerID
+			pr.DeathTick = d.Tick
+		} else {
+			pr.Alive = true
+			pr.Length = g.PlayerLen(id)
+		}
+		res.Players = append(res.Players, pr)
+	}
+	return res, nil
+}
+
+var csvHeader = []string{"game", "seed", "ticks", "id", "player", "alive", "length", "kills", "faults", "cause", "killer", "deathTick"}
+
+// writeCSV writes a row per player
+func writeCSV(w *csv.Writer, r Result) error {
+	for _, p := range r.Players {
+		err := w.Write([]string{
+			strconv.Itoa(r.Game),
+			strconv.FormatInt(r.Seed, 10),
+			strconv.Itoa(r.Ticks),
+			strconv.Itoa(int(p.ID)),
+			p.Player,
+			strconv.FormatBool(p.Alive),
+			strconv.Itoa(p.Length),
+			strconv.Itoa(p.Kills),
+			strconv.Itoa(p.Faults),
+			p.Cause,
+			strconv.Itoa(int(p.KillerID)),
+			strconv.Itoa(p.DeathTick),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wouterbeets/snake"
+	"github.com/wouterbeets/snake/ai"
+)
+
+func TestPlay(t *testing.T) {
+	remote := &snake.Random{}
+	accept := func() (snake.Player, error) { return remote, nil }
+	entries, err := ai.ParsePlayers("random,greedy,remote", ai.SpecOptions{Accept: accept})
+	require.NoError(t, err)
+	cfg := snake.GameConfig{Height: 20, Width: 20, NbFoodOnMap: 5, Seed: 3}
+	res, err := play(4, cfg, entries, 50)
+	require.NoError(t, err)
+	require.Equal(t, 4, res.Game)
+	require.Equal(t, int64(3), res.Seed)
+	require.True(t, res.Ticks <= 50)
+	require.Len(t, res.Players, 3)
+	require.Equal(t, "remote", res.Players[2].Player)
+	require.True(t, entries[2].New() == snake.Player(remote))
+	for _, p := range res.Players {
+		require.Equal(t, p.Alive, p.Cause == "")
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	require.NoError(t, writeCSV(w, res))
+	w.Flush()
+	rows, err := csv.NewReader(strings.NewReader(buf.String())).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+	require.Len(t, rows[0], len(csvHeader))
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/wouterbeets/snake"
+	"github.com/wouterbeets/snake/ai"
+	"github.com/wouterbeets/snake/api"
+	"github.com/wouterbeets/snake/bots"
+)
+
+// snakeapi serves games over http so they can be stepped from other languages,
+// see the api package for the endpoints. Players of a game are external or one of the
+// players allowed at startup, clients can't make the server open files or start commands
+func main() {
+	var (
+		addr       = flag.String("addr", "localhost:8080", "address to listen on")
+		allow      = flag.String("players", "random,"+strings.Join(bots.Names(), ","), "players games may use, "+ai.PlayerUsage)
+		cpath      = flag.String("config", "genn.json", "path to the configuration file used to load the networks")
+		vname      = flag.String("vision", snake.DefaultVision.Name(), "vision the networks were trained with, one of "+strings.Join(snake.Visions(), ", "))
+		maxSide    = flag.Int("max-side", api.DefaultMaxSide, "largest height and width of a board")
+		maxPlayers = flag.Int("max-players", api.DefaultMaxPlayers, "most players of a game")
+		maxTicks   = flag.Int("max-ticks", api.DefaultMaxTicks, "most ticks of one tick request")
+		maxGames   = flag.Int("max-games", api.DefaultMaxGames, "most games kept at once")
+	)
+	flag.Parse()
+
+	vision, err := snake.LookupVision(*vname)
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+
+	// games are played at the same time, so every game of an exec player gets its own process
+	specs, err := ai.ParsePlayers(*allow, ai.SpecOptions{
+		LoadNetworks:   ai.NetworkLoader(*cpath),
+		Vision:         vision,
+		Stderr:         os.Stderr,
+		ProcessPerGame: true,
+	})
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+	if *maxSide < 1 || *maxPlayers < 1 || *maxPlayers > snake.MaxPlayers || *maxTicks < 1 || *maxGames < 1 {
+		log.Fatalf("limits must be positive and at most %d players\n", snake.MaxPlayers)
+	}
+	s := &api.Server{
+		NewPlayer:  allowed(specs),
+		MaxSide:    *maxSide,
+		MaxPlayers: *maxPlayers,
+		MaxTicks:   *maxTicks,
+		MaxGames:   *maxGames,
+	}
+	log.Printf("listening on %s\n", *addr)
+	log.Fatalf("%+v\n", http.ListenAndServe(*addr, s))
+}
+
+// allowed returns a NewPlayer that only knows the players of specs
+func allowed(specs []ai.PlayerSpec) func(name string) (snake.Player, error) {
+	byName := make(map[string]ai.PlayerSpec, len(specs))
+	names := make([]string, 0, len(specs))
+	for _, sp := range specs {
+		if _, ok := byName[sp.Name]; !ok {
+			names = append(names, sp.Name)
+		}
+		byName[sp.Name] = sp
+	}
+	return func(name string) (snake.Player, error) {
+		sp, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("player %q is not allowed, use one of %s", name, strings.Join(names, ", "))
+		}
+		return sp.New(), nil
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/klokare/evo"
+	"github.com/stretchr/testify/require"
+	"github.com/wouterbeets/snake/ai"
+	"github.com/wouterbeets/snake/bots"
+)
+
+func TestAllowed(t *testing.T) {
+	specs, err := ai.ParsePlayers("survivor,ai.json", ai.SpecOptions{LoadNetworks: func(path string) ([]evo.Network, error) {
+		return make([]evo.Network, 2), nil
+	}})
+	require.NoError(t, err)
+	newPlayer := allowed(specs)
+
+	p, err := newPlayer("survivor")
+	require.NoError(t, err)
+	require.IsType(t, &bots.Survivor{}, p)
+	p, err = newPlayer("ai.json:1")
+	require.NoError(t, err)
+	require.IsType(t, &ai.NetWrapper{}, p)
+
+	// names outside the list are refused, files and commands alike
+	for _, name := range []string{"greedy", "ai.json", "other.json:0", "exec:sh", "/etc/passwd"} {
+		_, err = newPlayer(name)
+		require.Error(t, err, name)
+	}
+}
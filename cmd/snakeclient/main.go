@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/wouterbeets/snake"
+	"github.com/wouterbeets/snake/bots"
+)
+
+// snakeclient plays a bot in a game that waits for remote players,
+// for example one started with simulate -players remote,random -listen :7000.
+// With -join it takes a seat in a lobby of snakeserver
+func main() {
+	var (
+		addr = flag.String("addr", "localhost:7000", "address of the game")
+		name = flag.String("bot", "survivor", "bot to play, random or one of "+strings.Join(bots.Names(), ", "))
+		join = flag.String("join", "", "lobby to join on a snakeserver")
+		as   = flag.String("name", "", "name shown in the lobby, the bot name when empty")
+	)
+	flag.Parse()
+
+	player := *as
+	if player == "" {
+		player = *name
+	}
+	if err := play(context.Background(), *addr, *name, *join, player); err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+}
+
+// play connects to the game at addr and plays bot name until the game hangs up,
+// when lobby is set it first asks the server for a seat in it
+func play(ctx context.Context, addr, name, lobby, player string) error {
+	p, err := newPlayer(name)
+	if err != nil {
+		return err
+	}
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if lobby != "" {
+		req := map[string]string{"cmd": "join", "lobby": lobby, "name": player}
+		if err := json.NewEncoder(conn).Encode(req); err != nil {
+			return err
+		}
+	}
+	return snake.PlayRemote(ctx, conn, p)
+}
+
+func newPlayer(name string) (snake.Player, error) {
+	if name == "random" {
+		return &snake.Random{}, nil
+	}
+	return bots.New(name)
+}
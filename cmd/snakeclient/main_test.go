@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wouterbeets/snake"
+)
+
+func TestPlay(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	done := make(chan error)
+	go func() {
+		done <- play(context.Background(), l.Addr().String(), "survivor", "", "")
+	}()
+	remote, err := snake.AcceptPlayer(l)
+	require.NoError(t, err)
+
+	g, err := snake.NewGameWithConfig(snake.GameConfig{
+		Height:      15,
+		Width:       15,
+		Players:     []snake.Player{remote, &snake.Random{}},
+		NbFoodOnMap: 5,
+		Seed:        1,
+	})
+	require.NoError(t, err)
+	_, err = g.Run(context.Background(), snake.RunOptions{MaxTicks: 50})
+	require.NoError(t, err)
+	require.Empty(t, g.Faults(remote.ID))
+
+	remote.Close()
+	require.NoError(t, <-done)
+}
+
+func TestPlayRefused(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		bufio.NewReader(conn).ReadBytes('\n')
+		conn.Write([]byte(`{"error":"lobby \"lunch\" is full"}` + "\n"))
+	}()
+	err = play(context.Background(), l.Addr().String(), "survivor", "lunch", "bob")
+	require.ErrorContains(t, err, "is full")
+}
+
+func TestNewPlayer(t *testing.T) {
+	_, err := newPlayer("random")
+	require.NoError(t, err)
+	_, err = newPlayer("greedy")
+	require.NoError(t, err)
+	_, err = newPlayer("nobody")
+	require.Error(t, err)
+}
@@ -0,0 +1,408 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/wouterbeets/snake"
+	"github.com/wouterbeets/snake/ai"
+)
+
+// requestTimeout is how long a client gets to send its command
+const requestTimeout = 10 * time.Second
+
+// maxSeats is the most seats a lobby can have
+const maxSeats = 16
+
+// maxLobbies is the most lobbies a server holds at once
+const maxLobbies = 100
+
+// spectatorBuffer is the number of frames a spectator can fall behind before it is dropped
+const spectatorBuffer = 64
+
+// request is the first line a client sends
+type request struct {
+	Cmd   string `json:"cmd"`
+	Lobby string `json:"lobby"`
+	Name  string `json:"name"`
+	Seats int    `json:"seats"`
+}
+
+// reply answers create and list requests and requests that failed
+type reply struct {
+	Error   string      `json:"error,omitempty"`
+	Lobbies []lobbyInfo `json:"lobbies,omitempty"`
+}
+
+type lobbyInfo struct {
+	Name       string   `json:"name"`
+	Seats      int      `json:"seats"`
+	Players    []string `json:"players"`
+	Spectators int      `json:"spectators"`
+	Started    bool     `json:"started"`
+}
+
+// frame is sent to spectators. The first frame holds the whole board and the names of
+// the players, the next ones the cells that changed as x, y and the new value
+type frame struct {
+	Tick    int                 `json:"tick"`
+	Board   snake.Board         `json:"board,omitempty"`
+	Players map[snake.ID]string `json:"players,omitempty"`
+	Cells   [][3]int            `json:"cells,omitempty"`
+	Over    bool                `json:"over,omitempty"`
+	Alive   []snake.ID          `json:"alive,omitempty"`
+}
+
+// settings are the same for every lobby of a server
+type settings struct {
+	game  snake.GameConfig
+	seats int
+	wait  time.Duration
+	ticks int
+	// speed is the minimum time between two ticks so spectators can follow
+	speed time.Duration
+	// idle is how long a lobby nobody joined is kept, 0 keeps it until it is played
+	idle time.Duration
+	bots []ai.PlayerSpec
+}
+
+type server struct {
+	settings settings
+
+	mu      sync.Mutex
+	lobbies map[string]*lobby
+}
+
+type seat struct {
+	name   string
+	player snake.Player
+}
+
+// lobby is a game waiting for players or being played, its fields are guarded by the server
+type lobby struct {
+	name       string
+	seats      int
+	players    []seat
+	spectators []*spectator
+	started    bool
+	timer      *time.Timer
+	// board, tick and names are what a spectator that comes in late gets first
+	board snake.Board
+	tick  int
+	names map[snake.ID]string
+}
+
+// spectator writes the frames of a lobby to its connection in its own goroutine,
+// so a slow spectator doesn't hold up the game or the server
+type spectator struct {
+	conn   net.Conn
+	frames chan frame
+}
+
+func newSpectator(conn net.Conn) *spectator {
+	sp := &spectator{conn: conn, frames: make(chan frame, spectatorBuffer)}
+	go sp.write()
+	return sp
+}
+
+// write sends the frames until frames is closed or a write fails
+func (sp *spectator) write() {
+	enc := json.NewEncoder(sp.conn)
+	for f := range sp.frames {
+		sp.conn.SetWriteDeadline(time.Now().Add(time.Second))
+		if err := enc.Encode(f); err != nil {
+			break
+		}
+	}
+	sp.conn.Close()
+	// the lobby drops the spectator once its buffer is full and closes frames
+	for range sp.frames {
+	}
+}
+
+func newServer(s settings) *server {
+	return &server{settings: s, lobbies: make(map[string]*lobby)}
+}
+
+// serve handles the clients of l until l is closed
+func (s *server) serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *server) handle(conn net.Conn) {
+	// clients only send more once the game sent them something,
+	// so nothing after the request is lost in the buffer
+	conn.SetReadDeadline(time.Now().Add(requestTimeout))
+	line, err := bufio.NewReader(conn).ReadBytes('\n')
+	conn.SetReadDeadline(time.Time{})
+	var req request
+	if err == nil {
+		err = json.Unmarshal(line, &req)
+	}
+	if err != nil {
+		s.reply(conn, reply{Error: fmt.Sprintf("bad request: %v", err)})
+		return
+	}
+
+	switch req.Cmd {
+	case "create":
+		err = s.create(req.Lobby, req.Seats)
+	case "join":
+		name := req.Name
+		if name == "" {
+			name = conn.RemoteAddr().String()
+		}
+		if err = s.join(req.Lobby, name, conn); err == nil {
+			return
+		}
+	case "watch":
+		if err = s.watch(req.Lobby, conn); err == nil {
+			return
+		}
+	case "list":
+		s.reply(conn, reply{Lobbies: s.list()})
+		return
+	default:
+		err = fmt.Errorf("unknown command %q", req.Cmd)
+	}
+	if err != nil {
+		s.reply(conn, reply{Error: err.Error()})
+		return
+	}
+	s.reply(conn, reply{})
+}
+
+// reply answers the client and hangs up
+func (s *server) reply(conn net.Conn, r reply) {
+	conn.SetWriteDeadline(time.Now().Add(requestTimeout))
+	json.NewEncoder(conn).Encode(r)
+	conn.Close()
+}
+
+func (s *server) create(name string, seats int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err := s.newLobby(name, seats)
+	return err
+}
+
+// newLobby adds a lobby, seats <= 0 uses the seats of the settings
+func (s *server) newLobby(name string, seats int) (*lobby, error) {
+	if name == "" {
+		return nil, errors.New("lobby needs a name")
+	}
+	if _, ok := s.lobbies[name]; ok {
+		return nil, fmt.Errorf("lobby %q exists", name)
+	}
+	if seats <= 0 {
+		seats = s.settings.seats
+	}
+	if seats > maxSeats {
+		return nil, fmt.Errorf("a lobby has at most %d seats", maxSeats)
+	}
+	if len(s.lobbies) >= maxLobbies {
+		return nil, fmt.Errorf("the server has %d lobbies, try again later", maxLobbies)
+	}
+	g := s.settings.game
+	if cells := (g.Height - 2) * (g.Width - 2); 2*seats+g.NbFoodOnMap+1 > cells {
+		return nil, fmt.Errorf("%d seats don't fit on the board", seats)
+	}
+	l := &lobby{name: name, seats: seats}
+	s.lobbies[name] = l
+	if s.settings.idle > 0 {
+		time.AfterFunc(s.settings.idle, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if len(l.players) == 0 && s.lobbies[name] == l {
+				s.end(l, snake.Result{})
+			}
+		})
+	}
+	return l, nil
+}
+
+// join seats a remote player, the lobby is made when it doesn't exist
+func (s *server) join(name, player string, conn net.Conn) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.lobbies[name]
+	if !ok {
+		var err error
+		if l, err = s.newLobby(name, 0); err != nil {
+			return err
+		}
+	}
+	if l.started || len(l.players) >= l.seats {
+		return fmt.Errorf("lobby %q is full", name)
+	}
+	p := snake.NewRemotePlayer(conn)
+	p.Timeout = s.settings.game.MoveTimeout
+	l.players = append(l.players, seat{name: player, player: p})
+
+	switch {
+	case len(l.players) == l.seats:
+		if l.timer != nil {
+			l.timer.Stop()
+		}
+		s.start(l)
+	case len(l.players) == 1:
+		l.timer = time.AfterFunc(s.settings.wait, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.start(l)
+		})
+	}
+	return nil
+}
+
+// start fills the empty seats with bots and plays the game, the server must be locked
+func (s *server) start(l *lobby) {
+	if l.started {
+		return
+	}
+	l.started = true
+	for i := 0; len(l.players) < l.seats; i++ {
+		b := s.settings.bots[i%len(s.settings.bots)]
+		l.players = append(l.players, seat{name: b.Name, player: b.New()})
+	}
+	players := make([]snake.Player, len(l.players))
+	l.names = make(map[snake.ID]string, len(l.players))
+	for i, p := range l.players {
+		players[i] = p.player
+		l.names[snake.ID(i+2)] = p.name
+	}
+	go s.play(l, players)
+}
+
+func (s *server) play(l *lobby, players []snake.Player) {
+	cfg := s.settings.game
+	cfg.Players = players
+	cfg.Seed = time.Now().UnixNano()
+	g, err := snake.NewGameWithConfig(cfg)
+	if err != nil {
+		log.Printf("lobby %q: %v\n", l.name, err)
+		s.finish(l, snake.Result{})
+		return
+	}
+
+	s.mu.Lock()
+	l.board = g.Board()
+	s.broadcast(l, frame{Board: l.board, Players: l.names})
+	s.mu.Unlock()
+
+	last := time.Now()
+	res, _ := g.Run(context.Background(), snake.RunOptions{
+		MaxTicks: s.settings.ticks,
+		OnTick: func(b snake.Board) {
+			s.mu.Lock()
+			cells := diff(l.board, b)
+			l.board, l.tick = b, g.Tick()
+			s.broadcast(l, frame{Tick: l.tick, Cells: cells})
+			s.mu.Unlock()
+			if d := s.settings.speed - time.Since(last); d > 0 {
+				time.Sleep(d)
+			}
+			last = time.Now()
+		},
+	})
+	s.finish(l, res)
+}
+
+// finish tells the spectators who is left and closes all connections of the lobby
+func (s *server) finish(l *lobby, res snake.Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.end(l, res)
+}
+
+// end is finish for a locked server
+func (s *server) end(l *lobby, res snake.Result) {
+	s.broadcast(l, frame{Tick: res.Ticks, Over: true, Alive: res.Alive})
+	// the spectators hang up once they sent the last frame
+	for _, sp := range l.spectators {
+		close(sp.frames)
+	}
+	l.spectators = nil
+	// remote players are hung up on and the processes of exec bots stopped
+	for _, p := range l.players {
+		if c, ok := p.player.(io.Closer); ok {
+			c.Close()
+		}
+	}
+	delete(s.lobbies, l.name)
+}
+
+// broadcast queues f for all spectators and drops the ones that can't keep up,
+// the server must be locked
+func (s *server) broadcast(l *lobby, f frame) {
+	spectators := l.spectators[:0]
+	for _, sp := range l.spectators {
+		select {
+		case sp.frames <- f:
+			spectators = append(spectators, sp)
+		default:
+			close(sp.frames)
+		}
+	}
+	l.spectators = spectators
+}
+
+// watch adds a spectator, it gets the board right away when the game is running
+func (s *server) watch(name string, conn net.Conn) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.lobbies[name]
+	if !ok {
+		return fmt.Errorf("no lobby %q", name)
+	}
+	sp := newSpectator(conn)
+	if l.board != nil {
+		sp.frames <- frame{Tick: l.tick, Board: l.board, Players: l.names}
+	}
+	l.spectators = append(l.spectators, sp)
+	return nil
+}
+
+// list returns the lobbies by name
+func (s *server) list() []lobbyInfo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	infos := make([]lobbyInfo, 0, len(s.lobbies))
+	for _, l := range s.lobbies {
+		info := lobbyInfo{Name: l.name, Seats: l.seats, Spectators: len(l.spectators), Started: l.started}
+		for _, p := range l.players {
+			info.Players = append(info.Players, p.name)
+		}
+		infos = append(infos, info)
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// diff returns the cells of b that differ from prev as x, y and the value in b
+func diff(prev, b snake.Board) [][3]int {
+	var cells [][3]int
+	for y := range b {
+		for x := range b[y] {
+			if y >= len(prev) || x >= len(prev[y]) || prev[y][x] != b[y][x] {
+				cells = append(cells, [3]int{x, y, int(b[y][x])})
+			}
+		}
+	}
+	return cells
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wouterbeets/snake"
+	"github.com/wouterbeets/snake/ai"
+	"github.com/wouterbeets/snake/bots"
+)
+
+func testSettings() settings {
+	return settings{
+		game:  snake.GameConfig{Height: 15, Width: 15, NbFoodOnMap: 3, MoveTimeout: time.Second},
+		seats: 2,
+		wait:  50 * time.Millisecond,
+		ticks: 30,
+		speed: 5 * time.Millisecond,
+		idle:  time.Minute,
+		bots:  []ai.PlayerSpec{{Name: "greedy", New: func() snake.Player { return &bots.Greedy{} }}},
+	}
+}
+
+func testServer(t *testing.T, s *server) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	go s.serve(l)
+	return l.Addr().String()
+}
+
+// send dials the server and sends req, the reader holds the answers
+func send(t *testing.T, addr string, req request) (net.Conn, *bufio.Reader) {
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	require.NoError(t, json.NewEncoder(conn).Encode(req))
+	return conn, bufio.NewReader(conn)
+}
+
+func ask(t *testing.T, addr string, req request) reply {
+	_, r := send(t, addr, req)
+	var rep reply
+	require.NoError(t, json.NewDecoder(r).Decode(&rep))
+	return rep
+}
+
+func TestLobby(t *testing.T) {
+	addr := testServer(t, newServer(testSettings()))
+	require.Empty(t, ask(t, addr, request{Cmd: "create", Lobby: "lunch", Seats: 3}).Error)
+	require.NotEmpty(t, ask(t, addr, request{Cmd: "create", Lobby: "lunch"}).Error)
+	require.NotEmpty(t, ask(t, addr, request{Cmd: "create", Lobby: "huge", Seats: 100}).Error)
+	// the board of the test server has room for this many, the lobby doesn't
+	require.Contains(t, ask(t, addr, request{Cmd: "create", Lobby: "crowd", Seats: maxSeats + 1}).Error, "at most")
+	require.NotEmpty(t, ask(t, addr, request{Cmd: "watch", Lobby: "nothing"}).Error)
+	require.NotEmpty(t, ask(t, addr, request{Cmd: "dance"}).Error)
+
+	_, spectator := send(t, addr, request{Cmd: "watch", Lobby: "lunch"})
+	done := make(chan error, 2)
+	for _, name := range []string{"ann", "bob"} {
+		conn, _ := send(t, addr, request{Cmd: "join", Lobby: "lunch", Name: name})
+		go func() {
+			p, _ := bots.New("survivor")
+			done <- snake.PlayRemote(context.Background(), conn, p)
+		}()
+	}
+	require.Eventually(t, func() bool {
+		lobbies := ask(t, addr, request{Cmd: "list"}).Lobbies
+		return len(lobbies) == 1 && len(lobbies[0].Players) == 2
+	}, time.Second, 5*time.Millisecond)
+
+	// the third seat goes to a bot once the lobby waited long enough
+	dec := json.NewDecoder(spectator)
+	var f frame
+	require.NoError(t, dec.Decode(&f))
+	require.ElementsMatch(t, []string{"ann", "bob"}, []string{f.Players[2], f.Players[3]})
+	require.Equal(t, "greedy", f.Players[4])
+	require.Len(t, f.Board, 15)
+	board := f.Board
+	ticks := 0
+	for !f.Over {
+		f = frame{}
+		require.NoError(t, dec.Decode(&f))
+		for _, c := range f.Cells {
+			board[c[1]][c[0]] = int8(c[2])
+		}
+		ticks++
+	}
+	require.True(t, ticks > 1)
+	require.Equal(t, []int8{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1}, board[0])
+	require.True(t, f.Tick <= 30)
+
+	// players are let go and the lobby is gone
+	require.NoError(t, <-done)
+	require.NoError(t, <-done)
+	require.Empty(t, ask(t, addr, request{Cmd: "list"}).Lobbies)
+}
+
+func TestJoinFull(t *testing.T) {
+	addr := testServer(t, newServer(testSettings()))
+	for _, name := range []string{"ann", "bob"} {
+		conn, _ := send(t, addr, request{Cmd: "join", Lobby: "lunch", Name: name})
+		// random players can die in the first ticks and end the game before it is listed
+		go snake.PlayRemote(context.Background(), conn, &bots.Survivor{})
+	}
+	// the lobby made by join has two seats and starts right away
+	var lobbies []lobbyInfo
+	require.Eventually(t, func() bool {
+		lobbies = ask(t, addr, request{Cmd: "list"}).Lobbies
+		return len(lobbies) == 1 && lobbies[0].Started
+	}, time.Second, 5*time.Millisecond)
+	require.ElementsMatch(t, []string{"ann", "bob"}, lobbies[0].Players)
+	require.Contains(t, ask(t, addr, request{Cmd: "join", Lobby: "lunch", Name: "cat"}).Error, "full")
+}
+
+func TestIdleLobby(t *testing.T) {
+	cfg := testSettings()
+	cfg.idle = 50 * time.Millisecond
+	addr := testServer(t, newServer(cfg))
+	require.Empty(t, ask(t, addr, request{Cmd: "create", Lobby: "lunch"}).Error)
+	_, spectator := send(t, addr, request{Cmd: "watch", Lobby: "lunch"})
+	require.Len(t, ask(t, addr, request{Cmd: "list"}).Lobbies, 1)
+
+	// the spectator is told the lobby is over and hung up on
+	var f frame
+	dec := json.NewDecoder(spectator)
+	require.NoError(t, dec.Decode(&f))
+	require.True(t, f.Over)
+	require.Error(t, dec.Decode(&f))
+	require.Empty(t, ask(t, addr, request{Cmd: "list"}).Lobbies)
+}
+
+func TestMaxLobbies(t *testing.T) {
+	s := newServer(testSettings())
+	for i := 0; i < maxLobbies; i++ {
+		require.NoError(t, s.create(fmt.Sprint(i), 0))
+	}
+	require.Error(t, s.create("one more", 0))
+}
+
+func TestSlowSpectator(t *testing.T) {
+	s := newServer(testSettings())
+	require.NoError(t, s.create("lunch", 0))
+	// nothing reads the other end of the pipe, so every write blocks
+	conn, other := net.Pipe()
+	defer other.Close()
+	require.NoError(t, s.watch("lunch", conn))
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < spectatorBuffer+2; i++ {
+			s.mu.Lock()
+			s.broadcast(s.lobbies["lunch"], frame{Tick: i})
+			s.mu.Unlock()
+		}
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the broadcast waited for the spectator")
+	}
+	require.Zero(t, s.list()[0].Spectators)
+}
+
+func TestDiff(t *testing.T) {
+	prev := snake.Board{{1, 1}, {0, -1}}
+	b := snake.Board{{1, 1}, {2, 0}}
+	require.Equal(t, [][3]int{{0, 1, 2}, {1, 1, 0}}, diff(prev, b))
+	require.Len(t, diff(nil, b), 4)
+}
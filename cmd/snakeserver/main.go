@@ -0,0 +1,90 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/wouterbeets/snake"
+	"github.com/wouterbeets/snake/ai"
+)
+
+// snakeserver hosts games for remote players. A client sends one json line
+// with a command and the connection is then used for that command:
+//
+//	{"cmd":"create","lobby":"lunch","seats":6}  make a lobby with 6 seats
+//	{"cmd":"join","lobby":"lunch","name":"bob"} play in the lobby, it is made when missing
+//	{"cmd":"watch","lobby":"lunch"}             get the board and its changes every tick
+//	{"cmd":"list"}                              get the lobbies
+//
+// Players get the lines of snake.RemotePlayer once the game starts. A lobby starts
+// when its seats are taken or wait after the first player joined, empty seats go to bots.
+// A lobby nobody joins is removed after idle
+func main() {
+	var (
+		addr    = flag.String("addr", ":7000", "address to listen on")
+		fill    = flag.String("bots", "survivor,greedy,pathfinder", "players that fill empty seats, "+ai.PlayerUsage)
+		cpath   = flag.String("config", "genn.json", "path to the configuration file used to load the networks")
+		vname   = flag.String("vision", snake.DefaultVision.Name(), "vision the networks were trained with, one of "+strings.Join(snake.Visions(), ", "))
+		seats   = flag.Int("seats", 4, fmt.Sprintf("seats of a lobby made by join, at most %d", maxSeats))
+		wait    = flag.Duration("wait", 30*time.Second, "time a lobby waits for players after the first one joined")
+		idle    = flag.Duration("idle", 5*time.Minute, "time a created lobby is kept while nobody joined it")
+		height  = flag.Int("height", 30, "height of the board")
+		width   = flag.Int("width", 30, "width of the board")
+		food    = flag.Int("food", 10, "food on the board")
+		ticks   = flag.Int("ticks", 5000, "maximum number of ticks per game")
+		speed   = flag.Duration("speed", 100*time.Millisecond, "minimum time between two ticks")
+		timeout = flag.Duration("timeout", time.Second, "time a player gets for a move")
+	)
+	flag.Parse()
+
+	if *seats < 1 || *seats > maxSeats {
+		log.Fatalf("seats must be between 1 and %d\n", maxSeats)
+	}
+
+	vision, err := snake.LookupVision(*vname)
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+
+	// lobbies play at the same time, so every game of an exec bot gets its own process
+	fillers, err := ai.ParsePlayers(*fill, ai.SpecOptions{
+		LoadNetworks:   ai.NetworkLoader(*cpath),
+		Vision:         vision,
+		Stderr:         os.Stderr,
+		ProcessPerGame: true,
+	})
+	if err == nil && len(fillers) == 0 {
+		err = errors.New("no bots")
+	}
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+
+	l, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+	log.Printf("listening on %s\n", l.Addr())
+	s := newServer(settings{
+		game: snake.GameConfig{
+			Height:      *height,
+			Width:       *width,
+			NbFoodOnMap: *food,
+			MoveTimeout: *timeout,
+			Vision:      vision,
+		},
+		seats: *seats,
+		wait:  *wait,
+		ticks: *ticks,
+		speed: *speed,
+		idle:  *idle,
+		bots:  fillers,
+	})
+	log.Fatalf("%+v\n", s.serve(l))
+}
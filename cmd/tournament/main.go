@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/wouterbeets/snake"
+	"github.com/wouterbeets/snake/ai"
+	"github.com/wouterbeets/snake/tournament"
+)
+
+func main() {
+	var (
+		players = flag.String("players", "ai.json,end.json,random", ai.PlayerUsage)
+		cpath   = flag.String("config", "genn.json", "path to the configuration file used to load the networks")
+		vname   = flag.String("vision", snake.DefaultVision.Name(), "vision the networks were trained with, one of "+strings.Join(snake.Visions(), ", "))
+		mode    = flag.String("mode", "roundrobin", "pairing of the matches: roundrobin or swiss")
+		size    = flag.Int("size", 2, "players per match, 0 plays free for all matches")
+		repeat  = flag.Int("repeat", 1, "number of times every round robin match is played")
+		rounds  = flag.Int("rounds", 5, "number of swiss rounds")
+		ratings = flag.String("ratings", "ratings.json", "path of the ratings file, it is updated after the tournament")
+		height  = flag.Int("height", 30, "height of the board")
+		width   = flag.Int("width", 30, "width of the board")
+		food    = flag.Int("food", 10, "food on the board")
+		ticks   = flag.Int("ticks", 5000, "maximum number of ticks per match")
+		seed    = flag.Int64("seed", 1, "seed of the first match, match i uses seed+i")
+		timeout = flag.Duration("timeout", time.Second, "time a player gets for a move, 0 waits forever")
+	)
+	flag.Parse()
+
+	vision, err := snake.LookupVision(*vname)
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+
+	entrants, err := parsePlayers(*players, ai.SpecOptions{
+		LoadNetworks: ai.NetworkLoader(*cpath),
+		Vision:       vision,
+		Stderr:       os.Stderr,
+	})
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+	closers := track(entrants)
+
+	r, err := tournament.LoadRatings(*ratings)
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+	t := tournament.New(entrants, snake.GameConfig{
+		Height:      *height,
+		Width:       *width,
+		NbFoodOnMap: *food,
+		Seed:        *seed,
+		MoveTimeout: *timeout,
+	}, *ticks)
+	t.Ratings = r
+
+	switch *mode {
+	case "roundrobin":
+		err = t.RoundRobin(*size, *repeat)
+	case "swiss":
+		err = t.Swiss(*rounds, *size)
+	default:
+		err = fmt.Errorf("unknown mode %q", *mode)
+	}
+	for c := range closers {
+		c.Close()
+	}
+	if err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+
+	if err := t.Ratings.Save(*ratings); err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+	if err := t.Ratings.WriteLeaderboard(os.Stdout); err != nil {
+		log.Fatalf("%+v\n", err)
+	}
+}
+
+// track makes the entrants remember the players they create that hold a process or a connection,
+// the tournament closes them once it is over
+func track(entrants []tournament.Entrant) map[io.Closer]bool {
+	closers := make(map[io.Closer]bool)
+	for i := range entrants {
+		newPlayer := entrants[i].New
+		entrants[i].New = func() snake.Player {
+			p := newPlayer()
+			if c, ok := p.(io.Closer); ok {
+				closers[c] = true
+			}
+			return p
+		}
+	}
+	return closers
+}
+
+// parsePlayers reads the players flag, names are made unique so the same player can enter more than once
+func parsePlayers(spec string, opts ai.SpecOptions) ([]tournament.Entrant, error) {
+	specs, err := ai.ParsePlayers(spec, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(specs) < 2 {
+		return nil, errors.New("a tournament needs at least two players")
+	}
+	entrants := make([]tournament.Entrant, len(specs))
+	seen := make(map[string]int)
+	for i, s := range specs {
+		name := s.Name
+		seen[name]++
+		if seen[name] > 1 {
+			name = fmt.Sprintf("%s#%d", name, seen[name])
+		}
+		entrants[i] = tournament.Entrant{Name: name, New: s.New}
+	}
+	return entrants, nil
+}
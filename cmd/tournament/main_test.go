@@ -0,0 +1,54 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/klokare/evo"
+	"github.com/stretchr/testify/require"
+	"github.com/wouterbeets/snake/ai"
+)
+
+func TestParsePlayers(t *testing.T) {
+	opts := ai.SpecOptions{LoadNetworks: func(path string) ([]evo.Network, error) {
+		if path != "ai.json" {
+			return nil, errors.New("no such file")
+		}
+		return make([]evo.Network, 2), nil
+	}}
+	entrants, err := parsePlayers("random,ai.json,random,ai.json:1", opts)
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entrants {
+		names = append(names, e.Name)
+	}
+	require.Equal(t, []string{"random", "ai.json:0", "ai.json:1", "random#2", "ai.json:1#2"}, names)
+
+	_, err = parsePlayers("random", opts)
+	require.Error(t, err)
+	_, err = parsePlayers("random,end.json", opts)
+	require.Error(t, err)
+
+	// a process player plays all its games with the same command
+	entrants, err = parsePlayers("random,exec:python3 policy.py", opts)
+	require.NoError(t, err)
+	require.Equal(t, "exec:python3 policy.py", entrants[1].Name)
+	require.True(t, entrants[1].New() == entrants[1].New())
+}
+
+func TestTrack(t *testing.T) {
+	entrants, err := parsePlayers("random,exec:python3 policy.py", ai.SpecOptions{})
+	require.NoError(t, err)
+	closers := track(entrants)
+	for i := 0; i < 3; i++ {
+		for _, e := range entrants {
+			e.New()
+		}
+	}
+	// only the process is kept, once however many matches it played
+	require.Len(t, closers, 1)
+	for c := range closers {
+		require.Equal(t, entrants[1].New(), c)
+		require.NoError(t, c.Close())
+	}
+}
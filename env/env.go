@@ -0,0 +1,224 @@
+// Package env turns a game into a reinforcement learning environment: one snake
+// is controlled through Step, the other seats are played by opponents
+package env
+
+import (
+	"github.com/wouterbeets/snake"
+)
+
+// Action is the move of the controlled snake
+type Action int
+
+const (
+	Left Action = iota
+	Straight
+	Right
+)
+
+// Actions is the number of actions
+const Actions = 3
+
+func (a Action) String() string {
+	switch a {
+	case Left:
+		return "left"
+	case Straight:
+		return "straight"
+	case Right:
+		return "right"
+	}
+	return "unknown"
+}
+
+// Config describes the games of an environment
+type Config struct {
+	Height int
+	Width  int
+	Food   int
+	// Opponents creates the other players of every game, the controlled snake is always the first player
+	Opponents []func() snake.Player
+	// Vision is what the controlled snake sees, snake.DefaultVision is used when it is nil
+	Vision snake.VisionProvider
+	// MaxTicks ends an episode after that many steps, 0 plays until the snake dies or the game is over
+	MaxTicks int
+	// Reward scores a step, DefaultShaping is used when it is nil
+	Reward func(Info) float64
+}
+
+// Observation is what the controlled snake knows after a step
+type Observation struct {
+	// Inputs is what the vision sees followed by the life of the snake, like the inputs of the ai networks.
+	// It is all zeros once the snake is dead
+	Inputs []float64
+	Board  snake.Board
+	// Snake is the zero SnakeInfo once the snake is dead
+	Snake snake.SnakeInfo
+}
+
+// Info tells what happened in a step
+type Info struct {
+	Tick int
+	// Ate is the food eaten in the step
+	Ate int
+	// Length is the length after the step, the length it died with for a dead snake
+	Length int
+	// Grew is how much longer the snake got in the step, it is negative when it shrunk
+	Grew  int
+	Alive bool
+	// Cause and Killer tell how the snake died
+	Cause  snake.Cause
+	Killer snake.ID
+	// Truncated is set when the episode ended on MaxTicks
+	Truncated bool
+}
+
+// Env is an environment for one controlled snake, Reset starts an episode
+type Env struct {
+	cfg    Config
+	vision snake.VisionProvider
+	reward func(Info) float64
+
+	g      *snake.Game
+	agent  *agent
+	ate    int
+	length int
+	done   bool
+	obs    Observation
+}
+
+// agent plays the action given to Step
+type agent struct {
+	ID   snake.ID
+	move []float64
+}
+
+func (a *agent) Play(snake.GameState) snake.Move {
+	return snake.Move{Move: a.move, ID: a.ID}
+}
+
+func (a *agent) SetID(id snake.ID) {
+	a.ID = id
+}
+
+// New returns an environment, it fails when the players don't fit on the board.
+// Where the snakes spawn depends on the seed, so on crowded boards Reset can still fail
+func New(cfg Config) (*Env, error) {
+	e := &Env{cfg: cfg, vision: cfg.Vision, reward: cfg.Reward}
+	if e.vision == nil {
+		e.vision = snake.DefaultVision
+	}
+	if e.reward == nil {
+		e.reward = DefaultShaping.Reward
+	}
+	// stand-ins take the seats of the opponents, so no opponent is made only to check the board
+	seats := make([]snake.Player, len(cfg.Opponents)+1)
+	for i := range seats {
+		seats[i] = &agent{}
+	}
+	if _, err := e.newGame(0, seats); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *Env) newGame(seed int64, players []snake.Player) (*snake.Game, error) {
+	return snake.NewGameWithConfig(snake.GameConfig{
+		Height:      e.cfg.Height,
+		Width:       e.cfg.Width,
+		Players:     players,
+		NbFoodOnMap: e.cfg.Food,
+		Seed:        seed,
+		Vision:      e.vision,
+	})
+}
+
+// Size is the length of the Inputs of an observation
+func (e *Env) Size() int {
+	return e.vision.Size() + 1
+}
+
+// Reset starts a new episode, the same seed and actions play the same episode
+// as long as the opponents don't use randomness of their own. It fails when the snakes
+// can't all be placed with this seed, the previous episode is kept then
+func (e *Env) Reset(seed int64) (Observation, error) {
+	a := &agent{}
+	players := []snake.Player{a}
+	for _, o := range e.cfg.Opponents {
+		players = append(players, o())
+	}
+	g, err := e.newGame(seed, players)
+	if err != nil {
+		return Observation{}, err
+	}
+	e.agent, e.g = a, g
+	e.g.Subscribe(func(ev snake.Event) {
+		if ev.Type == snake.AteFood && ev.ID == e.agent.ID {
+			e.ate++
+		}
+	})
+	e.length = g.PlayerLen(e.agent.ID)
+	e.done = false
+	e.obs = e.observe()
+	return e.obs, nil
+}
+
+// Step plays one tick with the action of the controlled snake. Once done is returned
+// the episode is over and Step returns the last observation without reward until the next Reset
+func (e *Env) Step(a Action) (obs Observation, reward float64, done bool, info Info) {
+	if e.g == nil {
+		panic("env: Step before Reset")
+	}
+	id := e.agent.ID
+	if e.done {
+		return e.obs, 0, true, e.info()
+	}
+
+	e.agent.move = []float64{0, 0, 0}
+	if a < Left || a > Right {
+		a = Straight
+	}
+	e.agent.move[a] = 1
+	e.ate = 0
+	gameOver, _ := e.g.PlayRound()
+
+	info = e.info()
+	info.Ate = e.ate
+	info.Grew = info.Length - e.length
+	e.length = info.Length
+	info.Truncated = info.Alive && !gameOver && e.cfg.MaxTicks > 0 && e.g.Tick() >= e.cfg.MaxTicks
+	e.done = gameOver || !e.g.Alive(id) || info.Truncated
+
+	e.obs = e.observe()
+	return e.obs, e.reward(info), e.done, info
+}
+
+// Game returns the game of the episode, it is nil before the first Reset
+func (e *Env) Game() *snake.Game {
+	return e.g
+}
+
+func (e *Env) info() Info {
+	id := e.agent.ID
+	info := Info{Tick: e.g.Tick(), Alive: e.g.Alive(id)}
+	if info.Alive {
+		info.Length = e.g.PlayerLen(id)
+	} else if d, ok := e.g.Death(id); ok {
+		info.Length = d.Length
+		info.Cause = d.Cause
+		info.Killer = d.KillerID
+	}
+	return info
+}
+
+func (e *Env) observe() Observation {
+	id := e.agent.ID
+	obs := Observation{Board: e.g.Board()}
+	s, ok := e.g.Snake(id)
+	if !ok {
+		obs.Inputs = make([]float64, e.Size())
+		return obs
+	}
+	obs.Snake = s
+	obs.Inputs = append(e.g.Inputs(id), e.g.Life(id))
+	return obs
+}
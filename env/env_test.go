@@ -0,0 +1,161 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wouterbeets/snake"
+	"github.com/wouterbeets/snake/bots"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New(Config{Height: 3, Width: 3})
+	require.Error(t, err)
+	e, err := New(Config{Height: 10, Width: 10, Food: 2})
+	require.NoError(t, err)
+	require.Equal(t, snake.DefaultVision.Size()+1, e.Size())
+	require.Nil(t, e.Game())
+	require.Panics(t, func() { e.Step(Straight) })
+
+	// opponents are only made for episodes
+	var made int
+	e, err = New(Config{Height: 10, Width: 10, Opponents: []func() snake.Player{func() snake.Player {
+		made++
+		return &bots.Greedy{}
+	}}})
+	require.NoError(t, err)
+	require.Zero(t, made)
+	_, err = e.Reset(1)
+	require.NoError(t, err)
+	require.Equal(t, 1, made)
+}
+
+func TestResetCrowded(t *testing.T) {
+	random := func() snake.Player { return &snake.Random{} }
+	e, err := New(Config{Height: 5, Width: 5, Opponents: []func() snake.Player{random, random, random}})
+	require.NoError(t, err)
+
+	// the nine cells hold all snakes with some seeds only
+	var played, failed bool
+	for seed := int64(0); seed < 20; seed++ {
+		obs, err := e.Reset(seed)
+		if err != nil {
+			// the previous episode is kept
+			require.Equal(t, played, e.Game() != nil)
+			failed = true
+			continue
+		}
+		played = true
+		require.Equal(t, snake.ID(2), obs.Snake.ID)
+		e.Step(Straight)
+	}
+	require.True(t, failed)
+	require.True(t, played)
+}
+
+func TestWall(t *testing.T) {
+	e, err := New(Config{Height: 8, Width: 8, Food: 1})
+	require.NoError(t, err)
+	obs, err := e.Reset(1)
+	require.NoError(t, err)
+	require.Len(t, obs.Inputs, e.Size())
+	// the life of the snake is the last input
+	require.Equal(t, 1.0, obs.Inputs[e.Size()-1])
+	require.Equal(t, snake.ID(2), obs.Snake.ID)
+	require.Len(t, obs.Board, 8)
+
+	// going straight ends in a wall
+	var (
+		reward float64
+		done   bool
+		info   Info
+		steps  int
+	)
+	for !done {
+		obs, reward, done, info = e.Step(Straight)
+		steps++
+		require.True(t, steps < 8)
+		if !done {
+			require.True(t, info.Alive)
+			require.Equal(t, DefaultShaping.Survival, reward)
+		}
+	}
+	require.False(t, info.Alive)
+	require.Equal(t, snake.HitWall, info.Cause)
+	require.Equal(t, DefaultShaping.Death, reward)
+	require.Equal(t, make([]float64, e.Size()), obs.Inputs)
+	require.Equal(t, snake.SnakeInfo{}, obs.Snake)
+
+	_, reward, done, info = e.Step(Left)
+	require.True(t, done)
+	require.Zero(t, reward)
+	require.Equal(t, snake.HitWall, info.Cause)
+}
+
+func TestSeed(t *testing.T) {
+	play := func() []Observation {
+		e, err := New(Config{
+			Height:    12,
+			Width:     12,
+			Food:      3,
+			Opponents: []func() snake.Player{func() snake.Player { return &bots.Greedy{} }},
+		})
+		require.NoError(t, err)
+		o, err := e.Reset(7)
+		require.NoError(t, err)
+		obs := []Observation{o}
+		for _, a := range []Action{Left, Straight, Right, Right, Straight} {
+			o, _, done, _ := e.Step(a)
+			obs = append(obs, o)
+			if done {
+				break
+			}
+		}
+		return obs
+	}
+	first := play()
+	require.Equal(t, first, play())
+	var opponent bool
+	for _, row := range first[0].Board {
+		for _, c := range row {
+			opponent = opponent || c == 3
+		}
+	}
+	require.True(t, opponent)
+}
+
+func TestEat(t *testing.T) {
+	e, err := New(Config{Height: 10, Width: 10, Food: 3, MaxTicks: 60, Reward: Shaping{Food: 1, Length: 10}.Reward})
+	require.NoError(t, err)
+	_, err = e.Reset(3)
+	require.NoError(t, err)
+
+	// the greedy bot picks the actions
+	bot := &bots.Greedy{ID: 2}
+	var (
+		total, ate, grew int
+		done             bool
+		info             Info
+		reward           float64
+	)
+	for !done {
+		m := bot.Play(e.Game())
+		a := Straight
+		for i := range m.Move {
+			if m.Move[i] > m.Move[a] {
+				a = Action(i)
+			}
+		}
+		_, reward, done, info = e.Step(a)
+		require.Equal(t, float64(info.Ate)+10*float64(info.Grew), reward)
+		total++
+		ate += info.Ate
+		grew += info.Grew
+	}
+	require.True(t, ate > 0)
+	require.Equal(t, info.Length-2, grew)
+	if info.Alive {
+		require.True(t, info.Truncated)
+		require.Equal(t, 60, total)
+	}
+}
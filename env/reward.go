@@ -0,0 +1,27 @@
+package env
+
+// Shaping adds up weighted parts of a step, set a weight to 0 to leave its part out
+type Shaping struct {
+	// Food is given for every food eaten
+	Food float64
+	// Survival is given for every step the snake is still alive after
+	Survival float64
+	// Length is given for every cell the snake grew, shrinking costs the same
+	Length float64
+	// Death is given when the snake dies, make it negative for a penalty
+	Death float64
+}
+
+// DefaultShaping rewards eating and punishes dying
+var DefaultShaping = Shaping{Food: 1, Survival: 0.01, Death: -1}
+
+// Reward scores a step, it can be used as the Reward of a Config
+func (s Shaping) Reward(info Info) float64 {
+	r := s.Food*float64(info.Ate) + s.Length*float64(info.Grew)
+	if info.Alive {
+		r += s.Survival
+	} else {
+		r += s.Death
+	}
+	return r
+}
@@ -0,0 +1,16 @@
+package env
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShaping(t *testing.T) {
+	s := Shaping{Food: 2, Survival: 0.5, Length: 3, Death: -10}
+	require.Equal(t, 0.5, s.Reward(Info{Alive: true}))
+	require.Equal(t, 5.5, s.Reward(Info{Alive: true, Ate: 1, Grew: 1}))
+	require.Equal(t, -2.5, s.Reward(Info{Alive: true, Grew: -1}))
+	require.Equal(t, -10.0, s.Reward(Info{}))
+	require.Zero(t, Shaping{}.Reward(Info{Alive: true, Ate: 1}))
+}
@@ -0,0 +1,169 @@
+package snake
+
+// EventType tells what happened in the game
+type EventType int
+
+const (
+	// TickStarted is sent before the players are asked for their moves
+	TickStarted EventType = iota
+	// Moved is sent when a snake moved its head to a new cell
+	Moved
+	// AteFood is sent when a snake ate, it is sent after the Moved event
+	AteFood
+	// LifeDecayed is sent when a snake lost life by moving without eating
+	LifeDecayed
+	// Shrunk is sent when a snake lost its tail because it ran out of life
+	Shrunk
+	// Died is sent when a snake died, Cause and KillerID tell how
+	Died
+	// GameOver is sent when the last snake died
+	GameOver
+	// MoveFailed is sent when a player panicked or didn't give its move in time
+	MoveFailed
+)
+
+func (t EventType) String() string {
+	switch t {
+	case TickStarted:
+		return "tick started"
+	case Moved:
+		return "moved"
+	case AteFood:
+		return "ate food"
+	case LifeDecayed:
+		return "life decayed"
+	case Shrunk:
+		return "shrunk"
+	case Died:
+		return "died"
+	case GameOver:
+		return "game over"
+	case MoveFailed:
+		return "move failed"
+	}
+	return "unknown"
+}
+
+// Cause is the reason a snake died
+type Cause int
+
+const (
+	// NoCause is the cause of snakes that are still alive
+	NoCause Cause = iota
+	// HitWall is a snake that moved into a wall
+	HitWall
+	// HitSelf is a snake that moved into its own body
+	HitSelf
+	// HitSnake is a snake that moved into the body of another snake
+	HitSnake
+	// HeadOn is a snake that lost a cell another snake moved into in the same tick
+	HeadOn
+	// Starved is a snake that ran out of life with nothing left to shrink
+	Starved
+	// Faulted is a snake killed by the FaultKill policy
+	Faulted
+)
+
+func (c Cause) String() string {
+	switch c {
+	case NoCause:
+		return "none"
+	case HitWall:
+		return "wall"
+	case HitSelf:
+		return "self"
+	case HitSnake:
+		return "snake"
+	case HeadOn:
+		return "head on"
+	case Starved:
+		return "starved"
+	case Faulted:
+		return "faulted"
+	}
+	return "unknown"
+}
+
+// Event is sent to the subscribers of a game
+type Event struct {
+	Type EventType
+	Tick int
+	// ID is the player the event is about, it is 0 for TickStarted and GameOver
+	ID ID
+	// Cause and KillerID are only set for Died events
+	// KillerID is 0 when no other snake was involved
+	Cause    Cause
+	KillerID ID
+}
+
+// Subscribe registers f to be called for every event of the game.
+// f is called from the goroutine calling PlayRound, it must not call PlayRound itself
+func (g *Game) Subscribe(f func(Event)) {
+	g.subscribers = append(g.subscribers, f)
+}
+
+func (g *Game) emit(e Event) {
+	e.Tick = g.tick
+	for _, f := range g.subscribers {
+		f(e)
+	}
+}
+
+// DeathReport tells how a player died
+type DeathReport struct {
+	Cause Cause
+	// KillerID is 0 when no other snake was involved
+	KillerID ID
+	Tick     int
+	// Length is the length of the snake when it died
+	Length int
+}
+
+// died records the death of a player and sends the Died event,
+// it must be called before the player is removed from the game
+func (g *Game) died(id ID, cause Cause, killer ID) {
+	g.deaths[id] = DeathReport{
+		Cause:    cause,
+		KillerID: killer,
+		Tick:     g.tick,
+		Length:   g.PlayerLen(id),
+	}
+	g.emit(Event{Type: Died, ID: id, Cause: cause, KillerID: killer})
+}
+
+// Death returns how a player died, ok is false if the player didn't die
+func (g *Game) Death(id ID) (report DeathReport, ok bool) {
+	report, ok = g.deaths[id]
+	return
+}
+
+// Deaths returns the death reports of all dead players
+func (g *Game) Deaths() map[ID]DeathReport {
+	deaths := make(map[ID]DeathReport, len(g.deaths))
+	for id, d := range g.deaths {
+		deaths[id] = d
+	}
+	return deaths
+}
+
+// Kills returns the number of snakes killed by a player
+func (g *Game) Kills(id ID) (kills int) {
+	for _, d := range g.deaths {
+		if d.KillerID == id {
+			kills++
+		}
+	}
+	return
+}
+
+// collision returns why a snake moving into pos dies and the snake that killed it
+func (g *Game) collision(id ID, pos Position) (Cause, ID) {
+	cell := g.board[pos.y][pos.x]
+	switch {
+	case cell == wall:
+		return HitWall, 0
+	case ID(cell) == id:
+		return HitSelf, 0
+	}
+	return HitSnake, ID(cell)
+}
@@ -0,0 +1,70 @@
+package snake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubscribeDied(t *testing.T) {
+	g := newHeadOnGame(ResolveByID, 3)
+	var events []Event
+	g.Subscribe(func(e Event) {
+		events = append(events, e)
+	})
+	g.PlayRound()
+	require.Equal(t, []Event{
+		{Type: TickStarted},
+		{Type: Moved, ID: 2},
+		{Type: LifeDecayed, ID: 2},
+		{Type: Died, ID: 3, Cause: HitSnake, KillerID: 2},
+	}, events)
+
+	// snake 2 keeps going east until it hits the wall
+	events = nil
+	for gameOver := false; !gameOver; {
+		gameOver, _ = g.PlayRound()
+	}
+	require.Equal(t, Event{Type: Died, Tick: 5, ID: 2, Cause: HitWall}, events[len(events)-2])
+	require.Equal(t, Event{Type: GameOver, Tick: 5}, events[len(events)-1])
+}
+
+func TestSubscribeHeadOn(t *testing.T) {
+	g := newHeadOnGame(ResolveLongerWins, 4)
+	var died []Event
+	g.Subscribe(func(e Event) {
+		if e.Type == Died {
+			died = append(died, e)
+		}
+	})
+	g.PlayRound()
+	require.Equal(t, []Event{{Type: Died, ID: 3, Cause: HeadOn, KillerID: 2}}, died)
+}
+
+func TestSubscribeStarved(t *testing.T) {
+	g := newHeadOnGame(ResolveByID, 3)
+	g.removePlayer(3)
+	p := g.Players[2]
+	p.life = 0.01
+	g.Players[2] = p
+	var types []EventType
+	g.Subscribe(func(e Event) {
+		types = append(types, e.Type)
+	})
+	g.PlayRound()
+	require.Equal(t, []EventType{TickStarted, Moved, LifeDecayed, Shrunk}, types)
+	require.Equal(t, 2, g.PlayerLen(2))
+}
+
+func TestDeathReport(t *testing.T) {
+	g := newHeadOnGame(ResolveByID, 4)
+	g.PlayRound()
+	_, ok := g.Death(2)
+	require.False(t, ok)
+	d, ok := g.Death(3)
+	require.True(t, ok)
+	require.Equal(t, DeathReport{Cause: HitSnake, KillerID: 2, Tick: 0, Length: 3}, d)
+	require.Equal(t, 1, g.Kills(2))
+	require.Equal(t, 0, g.Kills(3))
+	require.Len(t, g.Deaths(), 1)
+}
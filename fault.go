@@ -0,0 +1,157 @@
+package snake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// FaultPolicy decides what happens to a player that panics or doesn't give its move in time
+type FaultPolicy int
+
+const (
+	// FaultStraight lets the snake go straight
+	FaultStraight FaultPolicy = iota
+	// FaultForfeit makes the snake skip the tick, it stays where it is
+	FaultForfeit
+	// FaultKill kills the snake with the Faulted cause
+	FaultKill
+)
+
+// ErrMoveTimeout is the fault of a player that didn't give its move before the deadline
+var ErrMoveTimeout = errors.New("move timeout")
+
+// Fault is a tick in which a player failed to give a move
+type Fault struct {
+	Tick int
+	Err  error
+}
+
+// Faults returns the faults of a player
+func (g *Game) Faults(id ID) []Fault {
+	return g.faults[id]
+}
+
+// collectMoves asks all players for their move and returns the moves in ID order.
+// Players that panic, take longer than the move timeout or are still busy with
+// a previous tick are handled by the fault policy
+func (g *Game) collectMoves() []Move {
+	ctx := g.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	var state GameState = g
+	var timeout <-chan time.Time
+	if g.moveTimeout > 0 {
+		// late players keep reading their state after the tick is played, so they get a copy
+		state = g.snapshot()
+		timer := time.NewTimer(g.moveTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+		// context players are told the deadline passed and stop once the moves are in
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.moveTimeout)
+		defer cancel()
+	}
+	if g.busy == nil {
+		g.busy = make(map[ID]chan struct{})
+	}
+
+	type result struct {
+		id   ID
+		move Move
+		err  error
+	}
+	results := make(chan result, len(g.Players))
+	waiting := make(map[ID]bool, len(g.Players))
+	faults := make(map[ID]error)
+	for id, p := range g.Players {
+		if done, ok := g.busy[id]; ok {
+			select {
+			case <-done:
+			default:
+				faults[id] = ErrMoveTimeout
+				continue
+			}
+		}
+		done := make(chan struct{})
+		g.busy[id] = done
+		waiting[id] = true
+		go func(id ID, p Player) {
+			defer close(done)
+			defer func() {
+				if r := recover(); r != nil {
+					results <- result{id: id, err: fmt.Errorf("player panicked: %v", r)}
+				}
+			}()
+			if cp, ok := p.(ContextPlayer); ok {
+				results <- result{id: id, move: cp.PlayContext(ctx, state)}
+				return
+			}
+			results <- result{id: id, move: p.Play(state)}
+		}(id, p.Player)
+	}
+
+	moves := make([]Move, 0, len(g.Players))
+collect:
+	for len(waiting) > 0 {
+		select {
+		case r := <-results:
+			delete(waiting, r.id)
+			if r.err != nil {
+				faults[r.id] = r.err
+				continue
+			}
+			r.move.ID = r.id
+			moves = append(moves, r.move)
+		case <-timeout:
+			for id := range waiting {
+				faults[id] = ErrMoveTimeout
+			}
+			break collect
+		}
+	}
+
+	// faults are handled in ID order so killing players doesn't depend on scheduling
+	faulted := make([]ID, 0, len(faults))
+	for id := range faults {
+		faulted = append(faulted, id)
+	}
+	sort.Slice(faulted, func(i, j int) bool { return faulted[i] < faulted[j] })
+	if g.faults == nil && len(faulted) > 0 {
+		g.faults = make(map[ID][]Fault)
+	}
+	for _, id := range faulted {
+		g.faults[id] = append(g.faults[id], Fault{Tick: g.tick, Err: faults[id]})
+		g.emit(Event{Type: MoveFailed, ID: id})
+		if g.recorder != nil {
+			g.recorder.fault(id)
+		}
+		switch g.faultPolicy {
+		case FaultStraight:
+			moves = append(moves, Move{Move: []float64{0, 1, 0}, ID: id})
+		case FaultKill:
+			g.died(id, Faulted, 0)
+			g.removePlayer(id)
+		}
+	}
+
+	sort.Slice(moves, func(i, j int) bool { return moves[i].ID < moves[j].ID })
+	return moves
+}
+
+// snapshot returns a copy of the game that players can read while the game goes on
+func (g *Game) snapshot() *Game {
+	s := &Game{
+		board:   g.Board(),
+		Players: make(map[ID]playerInfo, len(g.Players)),
+		tick:    g.tick,
+	}
+	for id, p := range g.Players {
+		p.snake = &snake{position: append([]Position(nil), p.snake.position...)}
+		s.Players[id] = p
+	}
+	return s
+}
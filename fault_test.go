@@ -0,0 +1,130 @@
+package snake
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// faultyPlayer goes straight but panics or sleeps in the ticks it is told to
+type faultyPlayer struct {
+	ID    ID
+	tick  int
+	panic map[int]bool
+	sleep map[int]time.Duration
+}
+
+func (f *faultyPlayer) Play(GameState) Move {
+	t := f.tick
+	f.tick++
+	if f.panic[t] {
+		panic("bad player")
+	}
+	time.Sleep(f.sleep[t])
+	return Move{Move: []float64{0, 1, 0}, ID: f.ID}
+}
+
+func (f *faultyPlayer) SetID(id ID) {
+	f.ID = id
+}
+
+func newFaultGame(t *testing.T, policy FaultPolicy, p Player, rec *Recorder) *Game {
+	g, err := NewGameWithConfig(GameConfig{
+		Height:      20,
+		Width:       20,
+		Players:     []Player{p, &straightPlayer{}},
+		Seed:        5,
+		MoveTimeout: 20 * time.Millisecond,
+		FaultPolicy: policy,
+		Recorder:    rec,
+	})
+	require.NoError(t, err)
+	return g
+}
+
+func TestFaultPanic(t *testing.T) {
+	g := newFaultGame(t, FaultStraight, &faultyPlayer{panic: map[int]bool{0: true}}, nil)
+	var failed []ID
+	g.Subscribe(func(e Event) {
+		if e.Type == MoveFailed {
+			failed = append(failed, e.ID)
+		}
+	})
+	head := g.Players[2].head()
+	dx, dy := g.Players[2].getDir().vector()
+	g.PlayRound()
+	require.Equal(t, []ID{2}, failed)
+	require.Len(t, g.Faults(2), 1)
+	require.Equal(t, 0, g.Faults(2)[0].Tick)
+	// the seed leaves room ahead, the snake went straight
+	require.True(t, g.Alive(2))
+	require.Equal(t, Position{x: head.x + dx, y: head.y + dy}, g.Players[2].head())
+}
+
+func TestFaultTimeoutForfeit(t *testing.T) {
+	g := newFaultGame(t, FaultForfeit, &faultyPlayer{sleep: map[int]time.Duration{0: 100 * time.Millisecond}}, nil)
+	head := g.Players[2].head()
+	start := time.Now()
+	g.PlayRound()
+	require.True(t, time.Since(start) < 100*time.Millisecond)
+	require.Equal(t, []Fault{{Tick: 0, Err: ErrMoveTimeout}}, g.Faults(2))
+	require.Equal(t, head, g.Players[2].head())
+
+	// the player is still busy with the first tick
+	g.PlayRound()
+	require.Len(t, g.Faults(2), 2)
+	require.Equal(t, head, g.Players[2].head())
+
+	time.Sleep(100 * time.Millisecond)
+	g.PlayRound()
+	require.Len(t, g.Faults(2), 2)
+}
+
+func TestFaultTimeoutContext(t *testing.T) {
+	g := newFaultGame(t, FaultForfeit, &waitingPlayer{}, nil)
+	g.PlayRound()
+	require.Equal(t, []Fault{{Tick: 0, Err: ErrMoveTimeout}}, g.Faults(2))
+
+	// the context of the tick ends with it, so the player isn't left busy
+	require.Eventually(t, func() bool {
+		select {
+		case <-g.busy[2]:
+			return true
+		default:
+			return false
+		}
+	}, time.Second, time.Millisecond)
+}
+
+func TestFaultKill(t *testing.T) {
+	g := newFaultGame(t, FaultKill, &faultyPlayer{panic: map[int]bool{1: true}}, nil)
+	g.PlayRound()
+	g.PlayRound()
+	require.False(t, g.Alive(2))
+	d, ok := g.Death(2)
+	require.True(t, ok)
+	require.Equal(t, Faulted, d.Cause)
+	require.Equal(t, 1, d.Tick)
+}
+
+func TestFaultReplay(t *testing.T) {
+	var buf bytes.Buffer
+	g := newFaultGame(t, FaultForfeit, &faultyPlayer{panic: map[int]bool{1: true, 2: true}}, NewRecorder(&buf))
+	boards := []Board{g.Board()}
+	for i := 0; i < 4; i++ {
+		g.PlayRound()
+		boards = append(boards, g.Board())
+	}
+
+	replay, err := LoadReplay(&buf)
+	require.NoError(t, err)
+	require.Equal(t, []ID{2}, replay.Ticks[1].Faults)
+	rg, err := replay.Game()
+	require.NoError(t, err)
+	for i := range replay.Ticks {
+		rg.PlayRound()
+		require.Equal(t, boards[i+1], rg.Board(), "tick %d", i)
+	}
+}
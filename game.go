@@ -1,9 +1,11 @@
 package snake
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"sync"
+	"math/rand"
+	"time"
 )
 
 const (
@@ -15,10 +17,27 @@ const (
 // ID is the player's id on the board
 type ID int8
 
+// MaxPlayers is the most players a game takes, their IDs start at 2 and stay clear of the int8 limit
+const MaxPlayers = 125
+
 // Game holds the board and the players
 type Game struct {
-	board   Board
-	Players map[ID]playerInfo
+	board       Board
+	Players     map[ID]playerInfo
+	rnd         *rand.Rand
+	resolution  Resolution
+	recorder    *Recorder
+	tick        int
+	subscribers []func(Event)
+	deaths      map[ID]DeathReport
+	moveTimeout time.Duration
+	faultPolicy FaultPolicy
+	faults      map[ID][]Fault
+	busy        map[ID]chan struct{}
+	// missingFood counts the food that found no free cell, it is placed once there is room
+	missingFood int
+	// ctx is the context of Run, it is handed to ContextPlayers
+	ctx context.Context
 }
 
 // The Board holds the game state
@@ -31,9 +50,35 @@ type Player interface {
 }
 
 type GameState interface {
+	// Vision is the raw view of the cells around the head used by SensorVision
 	Vision(id ID) []int8
+	// Inputs is the view of the player's vision provider
+	Inputs(id ID) []float64
+	// Window is a square of cells around the head rotated so the snake heads up
+	Window(id ID, side int) []float64
 	Life(id ID) float64 // 0 is dead
 	Board() Board
+
+	// Snake returns the player's snake, ok is false when it is dead
+	Snake(id ID) (info SnakeInfo, ok bool)
+	// Opponents returns the other snakes on the board
+	Opponents(id ID) []SnakeInfo
+	Food() []Position
+	Tick() int
+	Height() int
+	Width() int
+}
+
+// Runes draws the board for a terminal, every cell becomes the rune of its value
+func (b Board) Runes(runes map[int8]rune) [][]rune {
+	disp := make([][]rune, len(b))
+	for y, row := range b {
+		disp[y] = make([]rune, len(row))
+		for x, c := range row {
+			disp[y][x] = runes[c]
+		}
+	}
+	return disp
 }
 
 func (b Board) At(y, x int) int8 {
@@ -71,34 +116,116 @@ func newBoard(height, width int) Board {
 	return board
 }
 
+// GameConfig holds everything needed to set up a game.
+// Two games created with the same config and fed the same moves
+// produce the same sequence of boards.
+type GameConfig struct {
+	Height      int
+	Width       int
+	Players     []Player
+	NbFoodOnMap int
+	// Seed is used to create the random source when Rand is nil
+	Seed int64
+	// Rand is used for snake spawns and food placement if set
+	Rand *rand.Rand
+	// Resolution decides how moves of the same tick are settled
+	Resolution Resolution
+	// Recorder records the game so it can be replayed with LoadReplay
+	Recorder *Recorder
+	// MoveTimeout is how long a player gets to give its move, 0 waits forever
+	MoveTimeout time.Duration
+	// FaultPolicy decides what happens to players that panic or time out
+	FaultPolicy FaultPolicy
+	// Vision is the provider used for the Inputs of players that don't pick their own,
+	// DefaultVision is used when it is nil
+	Vision VisionProvider
+}
+
 // NewGame inits a new snake game with a size and list of players
+// The game is seeded with the current time
 func NewGame(height, width int, players []Player, nbFoodOnMap int) (*Game, error) {
-	if height < 5 || width < 5 {
+	return NewGameWithConfig(GameConfig{
+		Height:      height,
+		Width:       width,
+		Players:     players,
+		NbFoodOnMap: nbFoodOnMap,
+		Seed:        time.Now().UnixNano(),
+	})
+}
+
+// NewGameWithConfig inits a new snake game from a config
+func NewGameWithConfig(cfg GameConfig) (*Game, error) {
+	if cfg.Height < 5 || cfg.Width < 5 {
 		return nil, errors.New("size too small")
 	}
+	if cfg.NbFoodOnMap < 0 {
+		return nil, errors.New("negative amount of food")
+	}
+	if len(cfg.Players) > MaxPlayers {
+		return nil, fmt.Errorf("%d players, a game takes at most %d", len(cfg.Players), MaxPlayers)
+	}
+	// every snake starts with two cells, the game places one food more than NbFoodOnMap
+	if cells := (cfg.Height - 2) * (cfg.Width - 2); 2*len(cfg.Players)+cfg.NbFoodOnMap+1 > cells {
+		return nil, fmt.Errorf("%d players and %d food don't fit on %d cells", len(cfg.Players), cfg.NbFoodOnMap, cells)
+	}
+
+	// a replay is rebuilt from the seed, a game using its own Rand can't be replayed
+	if cfg.Recorder != nil && cfg.Rand != nil {
+		return nil, errors.New("a recorded game must use Seed, not Rand")
+	}
+
+	rnd := cfg.Rand
+	if rnd == nil {
+		rnd = rand.New(rand.NewSource(cfg.Seed))
+	}
 
 	g := &Game{
-		board:   newBoard(height, width),
-		Players: make(map[ID]playerInfo, len(players)),
+		board:       newBoard(cfg.Height, cfg.Width),
+		Players:     make(map[ID]playerInfo, len(cfg.Players)),
+		rnd:         rnd,
+		resolution:  cfg.Resolution,
+		recorder:    cfg.Recorder,
+		deaths:      make(map[ID]DeathReport),
+		moveTimeout: cfg.MoveTimeout,
+		faultPolicy: cfg.FaultPolicy,
+	}
+
+	vision := cfg.Vision
+	if vision == nil {
+		vision = DefaultVision
 	}
 
 	// Init players
-	for i, p := range players {
+	for i, p := range cfg.Players {
 		p.SetID(ID(i + 2))
-		g.Players[ID(i+2)] = playerInfo{
+		s, err := newSnake(g.board, ID(i+2), g.rnd)
+		if err != nil {
+			return nil, fmt.Errorf("spawning player %d: %w", ID(i+2), err)
+		}
+		info := playerInfo{
 			Player: p,
-			snake:  newSnake(g.board, ID(i+2)),
+			snake:  s,
 			life:   1,
+			maxLen: len(s.position),
+			vision: vision,
+		}
+		if vp, ok := p.(VisionPlayer); ok && vp.VisionProvider() != nil {
+			info.vision = vp.VisionProvider()
 		}
+		g.Players[ID(i+2)] = info
 	}
 
 	// Generate food
-	for i := 0; i <= nbFoodOnMap; i++ {
+	for i := 0; i <= cfg.NbFoodOnMap; i++ {
 		g.newFood()
 	}
+	if g.recorder != nil {
+		g.recorder.start(cfg)
+	}
 	return g, nil
 }
 
+// Position is a cell of the board, use X and Y to read it
 type Position struct {
 	x int
 	y int
@@ -109,6 +236,7 @@ type playerInfo struct {
 	*snake
 	life   float64
 	maxLen int
+	vision VisionProvider
 }
 
 func (g *Game) PlayerLen(id ID) int {
@@ -127,13 +255,19 @@ func (g *Game) Alive(id ID) bool {
 
 // Board returns a copy of the board
 func (g *Game) Board() (b Board) {
-	copy(b, g.board)
+	b = make(Board, len(g.board))
 	for i := range g.board {
+		b[i] = make([]int8, len(g.board[i]))
 		copy(b[i], g.board[i])
 	}
 	return
 }
 
+// Tick returns the number of rounds played
+func (g *Game) Tick() int {
+	return g.tick
+}
+
 func (g *Game) Life(id ID) float64 {
 	currentLen := float64(len(g.Players[id].snake.position) - 2)
 	maxLen := float64(g.Players[id].maxLen - 1)
@@ -144,34 +278,28 @@ func (g *Game) Life(id ID) float64 {
 
 // PlayRound processes one game tick
 func (g *Game) PlayRound() (gameOver bool, state Board) {
-	cmove := make(chan Move, len(g.Players))
-
-	var wg sync.WaitGroup
-
-	for i := range g.Players {
-		wg.Add(1)
-		go func(p Player) {
-			defer wg.Done()
-			m := p.Play(g)
-			cmove <- m
-		}(g.Players[i])
+	g.emit(Event{Type: TickStarted})
+	moves := g.collectMoves()
+	if g.recorder != nil {
+		g.recorder.moves(moves)
 	}
-	wg.Wait()
-	close(cmove)
 
-	for move := range cmove {
-		if _, ok := g.Players[move.ID]; !ok {
-			continue
-		}
-		if dead := g.PlayMove(move); dead {
-			for _, pos := range g.Players[move.ID].position {
-				g.board[pos.y][pos.x] = empty
-			}
-			delete(g.Players, move.ID)
-			if len(g.Players) == 0 {
-				return true, state
-			}
-		}
+	if g.resolution == ResolveByID {
+		g.playSequential(moves)
+	} else {
+		g.playSimultaneous(moves)
+	}
+	g.refillFood()
+	gameOver = len(g.Players) == 0 || g.Full()
+	if gameOver {
+		g.emit(Event{Type: GameOver})
+	}
+	g.tick++
+	if g.recorder != nil {
+		g.recorder.endTick()
+	}
+	if gameOver {
+		return true, state
 	}
 	return false, g.board
 }
@@ -182,16 +310,6 @@ func (g *Game) print() {
 	}
 }
 
-func (g *Game) newFood() {
-	for {
-		pos := randomPos(len(g.board[0]), len(g.board))
-		if g.board[pos.y][pos.x] == empty {
-			g.board[pos.y][pos.x] = food
-			return
-		}
-	}
-}
-
 func (g *Game) ThirdLayerVision(id ID) []int8 {
 	s := g.Players[id].snake
 	pos := s.head()
@@ -480,16 +598,21 @@ func (g *Game) PlayMove(m Move) (dead bool) {
 			p.maxLen = len(p.snake.position)
 		}
 		g.Players[m.ID] = p
+		g.emit(Event{Type: Moved, ID: m.ID})
+		g.emit(Event{Type: AteFood, ID: m.ID})
 		return false
 	}
 
 	if g.board[newPos.y][newPos.x] != empty {
+		cause, killer := g.collision(m.ID, newPos)
+		g.died(m.ID, cause, killer)
 		return true
 	}
 	t := p.snake.tail()
 	p.snake.moveTo(newPos, false)
 	g.board[newPos.y][newPos.x] = int8(m.ID)
 	g.board[t.y][t.x] = empty
+	g.emit(Event{Type: Moved, ID: m.ID})
 	dead = g.reduceLife(m.ID)
 	return
 }
@@ -497,13 +620,22 @@ func (g *Game) PlayMove(m Move) (dead bool) {
 func (g *Game) reduceLife(id ID) (dead bool) {
 	p := g.Players[id]
 	p.life -= 0.01
+	shrunk := false
 	if p.life <= 0 {
 		p.life = 1
 		t := p.snake.tail()
 		dead = p.reduceSize()
+		shrunk = !dead
 		g.board[t.y][t.x] = empty
 	}
 	g.Players[id] = p
+	g.emit(Event{Type: LifeDecayed, ID: id})
+	if shrunk {
+		g.emit(Event{Type: Shrunk, ID: id})
+	}
+	if dead {
+		g.died(id, Starved, 0)
+	}
 	return
 }
 
@@ -9,9 +9,9 @@ import (
 func TestNewGame(t *testing.T) {
 	width := 20
 	height := 20
-	game, _ := NewGame(20, 20, []Player{&Human{}})
-	require.Equal(t, width, len(game.Board))
-	require.Equal(t, height, len(game.Board[0]))
+	game, _ := NewGame(20, 20, []Player{&Human{}}, 1)
+	require.Equal(t, width, len(game.Board()))
+	require.Equal(t, height, len(game.Board()[0]))
 }
 
 func TestNewBoard(t *testing.T) {
@@ -20,3 +20,57 @@ func TestNewBoard(t *testing.T) {
 	require.Equal(t, height, len(board))
 	require.Equal(t, width, len(board[0]))
 }
+
+// straightPlayer always goes straight so the moves don't depend on the game's randomness
+type straightPlayer struct {
+	ID ID
+}
+
+func (s *straightPlayer) Play(GameState) Move {
+	return Move{Move: []float64{0, 1, 0}, ID: s.ID}
+}
+
+func (s *straightPlayer) SetID(id ID) {
+	s.ID = id
+}
+
+func TestBoardRunes(t *testing.T) {
+	b := Board{{1, 1, 1}, {1, -1, 2}}
+	require.Equal(t, [][]rune{{'#', '#', '#'}, {'#', 'o', 'x'}}, b.Runes(map[int8]rune{1: '#', -1: 'o', 2: 'x'}))
+}
+
+func TestLife(t *testing.T) {
+	g, err := NewGameWithConfig(GameConfig{Height: 20, Width: 20, Players: []Player{&straightPlayer{}}, Seed: 1})
+	require.NoError(t, err)
+	// a snake that didn't eat yet has all its life
+	require.Equal(t, 1.0, g.Life(2))
+	for g.Alive(2) {
+		l := g.Life(2)
+		require.True(t, l > 0 && l <= 1, "life %v at tick %d", l, g.Tick())
+		g.PlayRound()
+	}
+}
+
+func TestNewGameWithConfigSeed(t *testing.T) {
+	play := func(seed int64) []Board {
+		g, err := NewGameWithConfig(GameConfig{
+			Height:      15,
+			Width:       15,
+			Players:     []Player{&straightPlayer{}, &straightPlayer{}, &straightPlayer{}},
+			NbFoodOnMap: 5,
+			Seed:        seed,
+		})
+		require.NoError(t, err)
+		boards := []Board{g.Board()}
+		for i := 0; i < 20; i++ {
+			gameOver, _ := g.PlayRound()
+			boards = append(boards, g.Board())
+			if gameOver {
+				break
+			}
+		}
+		return boards
+	}
+	require.Equal(t, play(42), play(42))
+	require.NotEqual(t, play(42), play(43))
+}
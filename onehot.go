@@ -0,0 +1,91 @@
+package snake
+
+func init() {
+	RegisterVision(OneHotVision{})
+}
+
+// Channel is what a cell holds as seen by a player
+type Channel int
+
+// The channels, self and enemy are relative to the player looking at the cell
+const (
+	ChannelEmpty Channel = iota
+	ChannelWall
+	ChannelFood
+	ChannelSelfBody
+	ChannelSelfHead
+	ChannelEnemyBody
+	ChannelEnemyHead
+)
+
+// Channels is the number of channels a cell is encoded in
+const Channels = 7
+
+// Channel returns what the cell at y, x holds for player id, cells off the board are walls.
+// Unlike Board.At it tells the player's own body from other snakes and heads from bodies
+func (g *Game) Channel(id ID, y, x int) Channel {
+	if y < 0 || y >= len(g.board) || x < 0 || x >= len(g.board[y]) {
+		return ChannelWall
+	}
+	cell := g.board[y][x]
+	switch {
+	case cell == empty:
+		return ChannelEmpty
+	case cell == wall:
+		return ChannelWall
+	case cell == food:
+		return ChannelFood
+	}
+	owner := ID(cell)
+	head := Position{x: x, y: y} == g.Players[owner].snake.head()
+	switch {
+	case owner == id && head:
+		return ChannelSelfHead
+	case owner == id:
+		return ChannelSelfBody
+	case head:
+		return ChannelEnemyHead
+	}
+	return ChannelEnemyBody
+}
+
+// OneHotVision looks at the same cells as SensorVision, 5 cells along the rays to the left,
+// ahead left, ahead, ahead right and right of the head. Every cell is encoded as Channels
+// values of which only the one of the cell's Channel is 1
+type OneHotVision struct{}
+
+// Name implements VisionProvider
+func (OneHotVision) Name() string {
+	return "onehot"
+}
+
+// Size implements VisionProvider
+func (OneHotVision) Size() int {
+	return 5 * 5 * Channels
+}
+
+// See implements VisionProvider
+func (OneHotVision) See(g *Game, id ID) []float64 {
+	s := g.Players[id].snake
+	head := s.head()
+	fx, fy := s.getDir().vector()
+	rx, ry := -fy, fx
+	rays := [5][2]int{
+		{-rx, -ry},
+		{fx - rx, fy - ry},
+		{fx, fy},
+		{fx + rx, fy + ry},
+		{rx, ry},
+	}
+
+	in := make([]float64, 5*5*Channels)
+	i := 0
+	for _, ray := range rays {
+		for d := 1; d <= 5; d++ {
+			c := g.Channel(id, head.y+ray[1]*d, head.x+ray[0]*d)
+			in[i*Channels+int(c)] = 1
+			i++
+		}
+	}
+	return in
+}
@@ -0,0 +1,53 @@
+package snake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannel(t *testing.T) {
+	g := newHeadOnGame(ResolveByID, 3)
+	g.board[1][1] = food
+	require.Equal(t, ChannelWall, g.Channel(2, 0, 0))
+	require.Equal(t, ChannelWall, g.Channel(2, -1, 3))
+	require.Equal(t, ChannelFood, g.Channel(2, 1, 1))
+	require.Equal(t, ChannelEmpty, g.Channel(2, 3, 5))
+	require.Equal(t, ChannelSelfHead, g.Channel(2, 3, 4))
+	require.Equal(t, ChannelSelfBody, g.Channel(2, 3, 3))
+	require.Equal(t, ChannelEnemyHead, g.Channel(2, 3, 6))
+	require.Equal(t, ChannelEnemyBody, g.Channel(2, 3, 7))
+	require.Equal(t, ChannelEnemyBody, g.Channel(3, 3, 3))
+	require.Equal(t, ChannelSelfHead, g.Channel(3, 3, 6))
+}
+
+func TestOneHotVision(t *testing.T) {
+	g := newHeadOnGame(ResolveByID, 3)
+	in := OneHotVision{}.See(g, 2)
+	require.Len(t, in, OneHotVision{}.Size())
+	cell := func(ray, d int) Channel {
+		i := ray*5 + d - 1
+		for c := 0; c < Channels; c++ {
+			if in[i*Channels+c] == 1 {
+				return Channel(c)
+			}
+		}
+		return -1
+	}
+	// snake 2 heads east from (4,3), snake 3 has its head at (6,3)
+	require.Equal(t, ChannelEmpty, cell(2, 1))
+	require.Equal(t, ChannelEnemyHead, cell(2, 2))
+	require.Equal(t, ChannelEnemyBody, cell(2, 3))
+	require.Equal(t, ChannelEnemyBody, cell(2, 4))
+	require.Equal(t, ChannelEmpty, cell(2, 5))
+	// left is north, the wall is 3 cells up
+	require.Equal(t, ChannelEmpty, cell(0, 2))
+	require.Equal(t, ChannelWall, cell(0, 3))
+	// the sensor cells are the same
+	sensor := g.SensorVision(2)
+	for i, v := range sensor {
+		if v == wall {
+			require.Equal(t, ChannelWall, cell(i/5, i%5+1), "cell %d", i)
+		}
+	}
+}
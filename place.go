@@ -0,0 +1,58 @@
+package snake
+
+import "errors"
+
+// ErrBoardFull is returned when there is no free cell left to spawn a snake
+var ErrBoardFull = errors.New("board full")
+
+// freeCells returns the empty cells of the board row by row
+func (g *Game) freeCells() []Position {
+	var free []Position
+	for y, row := range g.board {
+		for x, cell := range row {
+			if cell == empty {
+				free = append(free, Position{x: x, y: y})
+			}
+		}
+	}
+	return free
+}
+
+// newFood places a food on a random empty cell. When the board is full
+// the food is counted as missing and placed by refillFood later
+func (g *Game) newFood() bool {
+	free := g.freeCells()
+	if len(free) == 0 {
+		g.missingFood++
+		return false
+	}
+	pos := free[g.rnd.Intn(len(free))]
+	g.board[pos.y][pos.x] = food
+	if g.recorder != nil {
+		g.recorder.spawnedFood(pos)
+	}
+	return true
+}
+
+// refillFood places the missing food as long as there are free cells
+func (g *Game) refillFood() {
+	for g.missingFood > 0 {
+		g.missingFood--
+		if !g.newFood() {
+			return
+		}
+	}
+}
+
+// Full reports if the snakes cover every cell, no snake can move or eat anymore
+// so the game is over and the snakes left have won
+func (g *Game) Full() bool {
+	for _, row := range g.board {
+		for _, cell := range row {
+			if cell <= empty {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+package snake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGameFits(t *testing.T) {
+	players := func(n int) []Player {
+		p := make([]Player, n)
+		for i := range p {
+			p[i] = &straightPlayer{}
+		}
+		return p
+	}
+	// a 5x5 board has 9 cells inside its walls
+	_, err := NewGameWithConfig(GameConfig{Height: 5, Width: 5, Players: players(5), NbFoodOnMap: 0})
+	require.Error(t, err)
+	_, err = NewGameWithConfig(GameConfig{Height: 5, Width: 5, Players: players(1), NbFoodOnMap: -1})
+	require.Error(t, err)
+	// the board has room but the ids would overflow
+	_, err = NewGameWithConfig(GameConfig{Height: 40, Width: 40, Players: players(MaxPlayers + 1)})
+	require.Error(t, err)
+	g, err := NewGameWithConfig(GameConfig{Height: 40, Width: 40, Players: players(MaxPlayers)})
+	require.NoError(t, err)
+	require.True(t, g.Alive(MaxPlayers+1))
+
+	for seed := int64(0); seed < 20; seed++ {
+		g, err := NewGameWithConfig(GameConfig{Height: 5, Width: 5, Players: players(1), NbFoodOnMap: 6, Seed: seed})
+		require.NoError(t, err)
+		require.Len(t, g.Food(), 7)
+		require.Empty(t, g.freeCells())
+		require.False(t, g.Full())
+	}
+}
+
+func TestRefillFood(t *testing.T) {
+	g, err := NewGameWithConfig(GameConfig{Height: 5, Width: 5, Players: []Player{&straightPlayer{}}, NbFoodOnMap: 6})
+	require.NoError(t, err)
+	require.False(t, g.newFood())
+	require.Equal(t, 1, g.missingFood)
+
+	g.refillFood()
+	require.Equal(t, 1, g.missingFood)
+
+	f := g.Food()[0]
+	g.board[f.y][f.x] = empty
+	g.refillFood()
+	require.Equal(t, 0, g.missingFood)
+	require.Len(t, g.Food(), 7)
+}
+
+func TestBoardFull(t *testing.T) {
+	g, err := NewGameWithConfig(GameConfig{Height: 5, Width: 5, Players: []Player{&straightPlayer{}}})
+	require.NoError(t, err)
+
+	// the snake heads east into the last food, eating it fills the board
+	for y := 1; y < 4; y++ {
+		for x := 1; x < 4; x++ {
+			g.board[y][x] = 2
+		}
+	}
+	g.board[1][3] = food
+	g.Players[2].snake.position = []Position{
+		{x: 1, y: 3}, {x: 2, y: 3}, {x: 3, y: 3}, {x: 3, y: 2},
+		{x: 2, y: 2}, {x: 1, y: 2}, {x: 1, y: 1}, {x: 2, y: 1},
+	}
+	require.False(t, g.Full())
+
+	var events []EventType
+	g.Subscribe(func(e Event) { events = append(events, e.Type) })
+	r, err := g.Run(context.Background(), RunOptions{MaxTicks: 10})
+	require.NoError(t, err)
+	require.True(t, r.GameOver)
+	require.True(t, r.Full)
+	require.Equal(t, 1, r.Ticks)
+	require.Equal(t, []ID{2}, r.Alive)
+	require.Equal(t, 9, g.PlayerLen(2))
+	require.Equal(t, GameOver, events[len(events)-1])
+}
@@ -1,6 +1,7 @@
 package snake
 
 import (
+	"context"
 	"math/rand"
 	"time"
 )
@@ -39,10 +40,19 @@ type Human struct {
 }
 
 func (h *Human) Play(gameState GameState) Move {
+	return h.PlayContext(context.Background(), gameState)
+}
+
+// PlayContext waits a frame for a key, it goes straight when no key is pressed or ctx is done
+func (h *Human) PlayContext(ctx context.Context, gameState GameState) Move {
+	timer := time.NewTimer(h.Framerate)
+	defer timer.Stop()
 	var key rune
 	select {
 	case key = <-h.Input:
-	case <-time.After(h.Framerate):
+	case <-timer.C:
+		key = '0'
+	case <-ctx.Done():
 		key = '0'
 	}
 	switch key {
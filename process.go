@@ -0,0 +1,148 @@
+package snake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"time"
+)
+
+// ProcessPlayer runs a command and plays the moves it writes to its stdout.
+// Every tick the state is written to the stdin of the command, the lines are
+// the ones of a RemotePlayer and PlayStdio turns any Player into such a command.
+// A command that exits is started again on the next tick
+type ProcessPlayer struct {
+	ID ID
+	// Path and Args are the command to run, see exec.Command
+	Path string
+	Args []string
+	// Timeout is how long the command gets for a move, 0 waits forever
+	Timeout time.Duration
+	// MaxRestarts is how often a command that exited is started again, 0 restarts it forever
+	MaxRestarts int
+	// Vision is sent to the command as its vision, nil uses the game's vision
+	Vision VisionProvider
+	// Stderr receives the stderr of the command, it is discarded when nil
+	Stderr io.Writer
+
+	cmd    *exec.Cmd
+	stdin  *os.File
+	stdout *os.File
+	lines  *lines
+	starts int
+}
+
+// NewProcessPlayer returns a player that runs the command name with args
+func NewProcessPlayer(name string, args ...string) *ProcessPlayer {
+	return &ProcessPlayer{Path: name, Args: args}
+}
+
+// Play implements Player
+func (p *ProcessPlayer) Play(gameState GameState) Move {
+	return p.PlayContext(context.Background(), gameState)
+}
+
+// PlayContext implements ContextPlayer, the move is abandoned when ctx is done
+func (p *ProcessPlayer) PlayContext(ctx context.Context, gameState GameState) Move {
+	if p.cmd == nil {
+		if err := p.start(); err != nil {
+			panic(err)
+		}
+	}
+	m, err := p.lines.play(ctx, newRemoteState(gameState, p.ID), p.Timeout)
+	if err != nil {
+		var syntax *json.SyntaxError
+		var typ *json.UnmarshalTypeError
+		if !errors.Is(err, ErrMoveTimeout) && !errors.As(err, &syntax) && !errors.As(err, &typ) && ctx.Err() == nil {
+			// the command exited or closed its end of the pipes
+			p.stop()
+		}
+		panic(err)
+	}
+	return m
+}
+
+// SetID implements Player
+func (p *ProcessPlayer) SetID(id ID) {
+	p.ID = id
+}
+
+// VisionProvider implements VisionPlayer
+func (p *ProcessPlayer) VisionProvider() VisionProvider {
+	return p.Vision
+}
+
+// Restarts returns how often the command was started again after it exited
+func (p *ProcessPlayer) Restarts() int {
+	if p.starts == 0 {
+		return 0
+	}
+	return p.starts - 1
+}
+
+// Close stops the command
+func (p *ProcessPlayer) Close() error {
+	p.stop()
+	return nil
+}
+
+func (p *ProcessPlayer) start() error {
+	if p.MaxRestarts > 0 && p.starts > p.MaxRestarts {
+		return fmt.Errorf("%s exited %d times", p.Path, p.starts)
+	}
+	p.starts++
+
+	inR, inW, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		inR.Close()
+		inW.Close()
+		return err
+	}
+	cmd := exec.Command(p.Path, p.Args...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = inR, outW, p.Stderr
+	// children of the command could keep stderr open after it is killed
+	cmd.WaitDelay = time.Second
+	err = cmd.Start()
+	// the command has its own copies of these ends
+	inR.Close()
+	outW.Close()
+	if err != nil {
+		inW.Close()
+		outR.Close()
+		return err
+	}
+
+	p.cmd, p.stdin, p.stdout = cmd, inW, outR
+	p.lines = newLines(outR, inW, func(t time.Time) error {
+		if err := inW.SetWriteDeadline(t); err != nil {
+			return err
+		}
+		return outR.SetReadDeadline(t)
+	})
+	return nil
+}
+
+func (p *ProcessPlayer) stop() {
+	if p.cmd == nil {
+		return
+	}
+	p.stdin.Close()
+	p.stdout.Close()
+	p.cmd.Process.Kill()
+	p.cmd.Wait()
+	p.cmd = nil
+}
+
+// PlayStdio is the other end of a ProcessPlayer, p plays the states read from stdin
+// and its moves are written to stdout. It returns nil once stdin is closed
+func PlayStdio(p Player) error {
+	return playLines(os.Stdin, os.Stdout, p)
+}
@@ -0,0 +1,72 @@
+package snake
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestProcessHelper is the command run by the process players of the tests,
+// it does nothing unless SNAKE_PROCESS_HELPER is set
+func TestProcessHelper(t *testing.T) {
+	switch os.Getenv("SNAKE_PROCESS_HELPER") {
+	case "":
+		return
+	case "straight":
+		PlayStdio(&straightPlayer{})
+	case "crash":
+		// answers the first state and exits on the second
+		r := bufio.NewReader(os.Stdin)
+		r.ReadBytes('\n')
+		os.Stdout.WriteString(`{"tick":0,"move":"r"}` + "\n")
+		r.ReadBytes('\n')
+		os.Exit(1)
+	case "silent":
+		bufio.NewReader(os.Stdin).WriteTo(io.Discard)
+	}
+	os.Exit(0)
+}
+
+func helperProcess(t *testing.T, mode string) *ProcessPlayer {
+	t.Setenv("SNAKE_PROCESS_HELPER", mode)
+	p := NewProcessPlayer(os.Args[0], "-test.run=^TestProcessHelper$")
+	t.Cleanup(func() { p.Close() })
+	return p
+}
+
+func TestProcessPlayer(t *testing.T) {
+	p := helperProcess(t, "straight")
+	require.Equal(t, playBoards(t, []Player{&straightPlayer{}, &straightPlayer{}}), playBoards(t, []Player{p, &straightPlayer{}}))
+	require.Equal(t, 0, p.Restarts())
+}
+
+func TestProcessPlayerRestart(t *testing.T) {
+	p := helperProcess(t, "crash")
+	p.MaxRestarts = 1
+	g, err := NewGameWithConfig(GameConfig{Height: 10, Width: 10, Players: []Player{p}, Seed: 1})
+	require.NoError(t, err)
+
+	require.Equal(t, []float64{0, 0, 1}, p.Play(g).Move)
+	require.Panics(t, func() { p.Play(g) })
+	require.Equal(t, []float64{0, 0, 1}, p.Play(g).Move)
+	require.Equal(t, 1, p.Restarts())
+	require.Panics(t, func() { p.Play(g) })
+
+	// no more restarts
+	require.PanicsWithError(t, os.Args[0]+" exited 2 times", func() { p.Play(g) })
+}
+
+func TestProcessPlayerTimeout(t *testing.T) {
+	p := helperProcess(t, "silent")
+	p.Timeout = 50 * time.Millisecond
+	g, err := NewGameWithConfig(GameConfig{Height: 10, Width: 10, Players: []Player{p}, Seed: 1})
+	require.NoError(t, err)
+
+	require.PanicsWithError(t, ErrMoveTimeout.Error(), func() { p.Play(g) })
+	require.PanicsWithError(t, ErrMoveTimeout.Error(), func() { p.Play(g) })
+	require.Equal(t, 0, p.Restarts())
+}
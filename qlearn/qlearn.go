@@ -0,0 +1,163 @@
+// Package qlearn is a tabular Q-learning player. It learns the value of every move
+// for a small encoding of what is around the head, next to the NEAT networks of the ai package
+package qlearn
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+
+	"github.com/wouterbeets/snake"
+	"github.com/wouterbeets/snake/bots"
+	"github.com/wouterbeets/snake/env"
+)
+
+// Table holds the value of the left, straight and right move of every state seen
+type Table map[string][env.Actions]float64
+
+// Best returns the move with the highest value, straight wins ties so unseen states go straight
+func (t Table) Best(state string) env.Action {
+	q := t[state]
+	best := env.Straight
+	for _, a := range []env.Action{env.Left, env.Right} {
+		if q[a] > q[best] {
+			best = a
+		}
+	}
+	return best
+}
+
+// Load reads a table saved with Save
+func Load(path string) (Table, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	t := make(Table)
+	if err := json.NewDecoder(file).Decode(&t); err != nil {
+		return nil, fmt.Errorf("reading table %s: %v", path, err)
+	}
+	return t, nil
+}
+
+// Save writes the table as json to path
+func (t Table) Save(path string) error {
+	b, err := json.MarshalIndent(t, "", "\t")
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(b); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Agent plays the best move of its table, with Epsilon > 0 it explores random moves that often
+type Agent struct {
+	ID      snake.ID
+	Table   Table
+	Epsilon float64
+	// Rand picks the random moves, the global source is used when it is nil
+	Rand *rand.Rand
+}
+
+// Play implements snake.Player
+func (a *Agent) Play(gs snake.GameState) snake.Move {
+	m := snake.Move{Move: []float64{0, 0, 0}, ID: a.ID}
+	m.Move[a.act(Encode(gs, a.ID))] = 1
+	return m
+}
+
+// SetID implements snake.Player
+func (a *Agent) SetID(id snake.ID) {
+	a.ID = id
+}
+
+func (a *Agent) act(state string) env.Action {
+	if a.Epsilon > 0 && a.float() < a.Epsilon {
+		return env.Action(a.intn(env.Actions))
+	}
+	return a.Table.Best(state)
+}
+
+func (a *Agent) float() float64 {
+	if a.Rand == nil {
+		return rand.Float64()
+	}
+	return a.Rand.Float64()
+}
+
+func (a *Agent) intn(n int) int {
+	if a.Rand == nil {
+		return rand.Intn(n)
+	}
+	return a.Rand.Intn(n)
+}
+
+// cells around the head in the encoding
+const (
+	deadly  = '0'
+	trapped = '1'
+	open    = '2'
+	food    = '3'
+)
+
+// Encode returns the state of player id. Every move is deadly, trapped when the room behind it
+// is smaller than the snake, open or food, followed by where the closest food is: ahead or behind
+// and left or right of the head
+func Encode(gs snake.GameState, id snake.ID) string {
+	self, ok := gs.Snake(id)
+	if !ok {
+		return ""
+	}
+	b := gs.Board()
+	fx, fy := self.Heading.Vector()
+	// left turns the heading a quarter counter clockwise, y grows downwards
+	lx, ly := fy, -fx
+	steps := [env.Actions][2]int{{lx, ly}, {fx, fy}, {-lx, -ly}}
+
+	state := make([]byte, 0, env.Actions+2)
+	for a, safety := range bots.Safety(gs, id) {
+		p := snake.NewPosition(self.Head.X()+steps[a][0], self.Head.Y()+steps[a][1])
+		switch {
+		case safety == 0:
+			state = append(state, deadly)
+		case b.At(p.Y(), p.X()) == -1:
+			state = append(state, food)
+		case safety <= self.Length:
+			state = append(state, trapped)
+		default:
+			state = append(state, open)
+		}
+	}
+
+	var closest snake.Position
+	dist := -1
+	for _, f := range gs.Food() {
+		if d := f.Distance(self.Head); dist < 0 || d < dist {
+			closest, dist = f, d
+		}
+	}
+	if dist < 0 {
+		return string(append(state, '-', '-'))
+	}
+	dx, dy := closest.X()-self.Head.X(), closest.Y()-self.Head.Y()
+	return string(append(state, sign(dx*fx+dy*fy), sign(dx*lx+dy*ly)))
+}
+
+func sign(i int) byte {
+	switch {
+	case i < 0:
+		return '-'
+	case i > 0:
+		return '+'
+	}
+	return '0'
+}
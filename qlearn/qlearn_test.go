@@ -0,0 +1,153 @@
+package qlearn
+
+import (
+	"math/rand"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wouterbeets/snake"
+	"github.com/wouterbeets/snake/env"
+)
+
+// boardState is a game state made from a drawn board with one snake,
+// # are walls, F food and 2 the snake
+type boardState struct {
+	snake.GameState
+	board snake.Board
+	self  snake.SnakeInfo
+}
+
+func newBoardState(h snake.Heading, body []snake.Position, rows ...string) boardState {
+	b := make(snake.Board, len(rows))
+	for y, row := range rows {
+		b[y] = make([]int8, len(row))
+		for x, c := range row {
+			switch c {
+			case '#':
+				b[y][x] = 1
+			case 'F':
+				b[y][x] = -1
+			case '2':
+				b[y][x] = 2
+			}
+		}
+	}
+	self := snake.SnakeInfo{ID: 2, Head: body[len(body)-1], Body: body, Heading: h, Length: len(body)}
+	return boardState{board: b, self: self}
+}
+
+func (s boardState) Board() snake.Board { return s.board }
+
+func (s boardState) Food() (food []snake.Position) {
+	for y := range s.board {
+		for x := range s.board[y] {
+			if s.board[y][x] < 0 {
+				food = append(food, snake.NewPosition(x, y))
+			}
+		}
+	}
+	return
+}
+
+func (s boardState) Snake(id snake.ID) (snake.SnakeInfo, bool) {
+	return s.self, id == s.self.ID
+}
+
+func (s boardState) Opponents(snake.ID) []snake.SnakeInfo { return nil }
+
+func TestEncode(t *testing.T) {
+	north := []snake.Position{snake.NewPosition(3, 5), snake.NewPosition(3, 4)}
+	gs := newBoardState(snake.North, north,
+		"#######",
+		"#.....#",
+		"#..F..#",
+		"#.....#",
+		"#..2..#",
+		"#..2..#",
+		"#######",
+	)
+	require.Equal(t, "222+0", Encode(gs, 2))
+	require.Equal(t, "", Encode(gs, 3))
+
+	gs = newBoardState(snake.North, north,
+		"#######",
+		"#.....#",
+		"#.....#",
+		"#..F..#",
+		"#..2..#",
+		"#..2..#",
+		"#######",
+	)
+	require.Equal(t, "232+0", Encode(gs, 2))
+
+	// food behind and on the left of a snake going east into the wall
+	gs = newBoardState(snake.East, []snake.Position{snake.NewPosition(4, 4), snake.NewPosition(5, 4)},
+		"#######",
+		"#.....#",
+		"#..F..#",
+		"#.....#",
+		"#...22#",
+		"#.....#",
+		"#######",
+	)
+	require.Equal(t, "202-+", Encode(gs, 2))
+
+	// a pocket of one cell is too small for a snake of three
+	gs = newBoardState(snake.North, []snake.Position{snake.NewPosition(3, 5), snake.NewPosition(3, 4), snake.NewPosition(3, 3)},
+		"######",
+		"#....#",
+		"#.#..#",
+		"##.2.#",
+		"#.#2.#",
+		"#..2.#",
+		"######",
+	)
+	require.Equal(t, "122--", Encode(gs, 2))
+}
+
+func TestBest(t *testing.T) {
+	tb := Table{"a": {1, 0, 2}, "b": {-1, 0, -1}, "c": {3, 3, 1}}
+	require.Equal(t, env.Right, tb.Best("a"))
+	require.Equal(t, env.Straight, tb.Best("b"))
+	require.Equal(t, env.Straight, tb.Best("c"))
+	require.Equal(t, env.Straight, tb.Best("unseen"))
+}
+
+func TestAgent(t *testing.T) {
+	gs := newBoardState(snake.North, []snake.Position{snake.NewPosition(2, 3), snake.NewPosition(2, 2)},
+		"#####",
+		"#...#",
+		"#.2.#",
+		"#.2.#",
+		"#####",
+	)
+	a := &Agent{Table: Table{"222--": {0, 0, 1}}}
+	a.SetID(2)
+	require.Equal(t, snake.Move{Move: []float64{0, 0, 1}, ID: 2}, a.Play(gs))
+
+	// always exploring picks every move
+	a.Epsilon, a.Rand = 1, rand.New(rand.NewSource(1))
+	seen := make(map[int]bool)
+	for i := 0; i < 50; i++ {
+		m := a.Play(gs).Move
+		for j := range m {
+			if m[j] == 1 {
+				seen[j] = true
+			}
+		}
+	}
+	require.Len(t, seen, 3)
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "q.json")
+	tb := Table{"222+0": {0.5, 1, -1}, "000--": {-1, -1, -1}}
+	require.NoError(t, tb.Save(path))
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	require.Equal(t, tb, loaded)
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.json"))
+	require.Error(t, err)
+}
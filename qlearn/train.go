@@ -0,0 +1,77 @@
+package qlearn
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/wouterbeets/snake/env"
+)
+
+// Trainer plays episodes of an environment with an epsilon greedy agent and
+// updates the table after every step
+type Trainer struct {
+	Env env.Config
+	// Alpha is the learning rate
+	Alpha float64
+	// Gamma discounts the value of the next state
+	Gamma float64
+	// Epsilon is the exploration of the first episode, it is multiplied by Decay
+	// after every episode until it reaches MinEpsilon, a Decay of 0 keeps it
+	Epsilon    float64
+	Decay      float64
+	MinEpsilon float64
+	// Seed seeds the exploration, episode i is played on the board of seed Seed+i
+	Seed int64
+	// OnEpisode is called after every episode with its total reward and the last info
+	OnEpisode func(episode int, reward float64, info env.Info)
+}
+
+// Train plays episodes and learns them into t, t must not be nil
+func (tr Trainer) Train(t Table, episodes int) error {
+	e, err := env.New(tr.Env)
+	if err != nil {
+		return err
+	}
+	agent := &Agent{Table: t, Epsilon: tr.Epsilon, Rand: rand.New(rand.NewSource(tr.Seed))}
+	for i := 0; i < episodes; i++ {
+		obs, err := e.Reset(tr.Seed + int64(i))
+		if err != nil {
+			return fmt.Errorf("episode %d: %v", i, err)
+		}
+		agent.SetID(obs.Snake.ID)
+		var total float64
+		state := Encode(e.Game(), agent.ID)
+		for {
+			a := agent.act(state)
+			_, reward, done, info := e.Step(a)
+			total += reward
+
+			// an episode cut off by MaxTicks goes on from the next state, only a death ends its value
+			target := reward
+			next := ""
+			if !done || info.Truncated && info.Alive {
+				next = Encode(e.Game(), agent.ID)
+				q := t[next]
+				target += tr.Gamma * q[t.Best(next)]
+			}
+			q := t[state]
+			q[a] += tr.Alpha * (target - q[a])
+			t[state] = q
+
+			if done {
+				if tr.OnEpisode != nil {
+					tr.OnEpisode(i, total, info)
+				}
+				break
+			}
+			state = next
+		}
+		if tr.Decay == 0 {
+			continue
+		}
+		if agent.Epsilon *= tr.Decay; agent.Epsilon < tr.MinEpsilon {
+			agent.Epsilon = tr.MinEpsilon
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package qlearn
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wouterbeets/snake"
+	"github.com/wouterbeets/snake/env"
+)
+
+func TestTrain(t *testing.T) {
+	cfg := env.Config{Height: 10, Width: 10, Food: 3, MaxTicks: 200}
+	tb := make(Table)
+	var episodes int
+	tr := Trainer{Env: cfg, Alpha: 0.2, Gamma: 0.9, Epsilon: 0.5, Decay: 0.99, MinEpsilon: 0.05, Seed: 1,
+		OnEpisode: func(int, float64, env.Info) { episodes++ },
+	}
+	require.NoError(t, tr.Train(tb, 300))
+	require.Equal(t, 300, episodes)
+	require.NotEmpty(t, tb)
+
+	// the learned table eats more than going straight on boards it never saw
+	eaten := func(tb Table) (food int) {
+		for seed := int64(1000); seed < 1010; seed++ {
+			a := &Agent{Table: tb}
+			g, err := snake.NewGameWithConfig(snake.GameConfig{Height: 10, Width: 10, NbFoodOnMap: 3, Seed: seed, Players: []snake.Player{a}})
+			require.NoError(t, err)
+			g.Subscribe(func(e snake.Event) {
+				if e.Type == snake.AteFood {
+					food++
+				}
+			})
+			_, err = g.Run(context.Background(), snake.RunOptions{MaxTicks: 200})
+			require.NoError(t, err)
+		}
+		return food
+	}
+	require.True(t, eaten(tb) > eaten(Table{})+10)
+
+	require.Error(t, Trainer{Env: env.Config{Height: 3, Width: 3}}.Train(tb, 1))
+}
+
+func TestTrainTruncated(t *testing.T) {
+	// every episode ends after one step on a board the snake can't die on in that step
+	cfg := env.Config{Height: 10, Width: 10, Food: 1, MaxTicks: 1}
+	e, err := env.New(cfg)
+	require.NoError(t, err)
+	_, err = e.Reset(5)
+	require.NoError(t, err)
+	first := Encode(e.Game(), 2)
+	_, reward, done, info := e.Step(Table{}.Best(first))
+	require.True(t, done)
+	require.True(t, info.Truncated)
+	next := Encode(e.Game(), 2)
+
+	// the value of the state after the cut still counts
+	tb := Table{next: {10, 10, 10}}
+	require.NoError(t, Trainer{Env: cfg, Alpha: 1, Gamma: 1, Seed: 5}.Train(tb, 1))
+	require.InDelta(t, reward+10, tb[first][env.Straight], 1e-9)
+}
@@ -0,0 +1,82 @@
+package snake
+
+func init() {
+	RegisterVision(RaycastVision{})
+}
+
+// RaycastVision looks in 8 directions around the head, starting straight ahead
+// and going clockwise. For every direction it returns 4 values: the wall, the food,
+// the snake's own body and the body of another snake. A value is 1/distance to the
+// nearest cell of that kind, so an adjacent cell is 1 and 0 means there is none
+type RaycastVision struct{}
+
+// Name implements VisionProvider
+func (RaycastVision) Name() string {
+	return "raycast"
+}
+
+// Size implements VisionProvider
+func (RaycastVision) Size() int {
+	return 8 * 4
+}
+
+// See implements VisionProvider
+func (RaycastVision) See(g *Game, id ID) []float64 {
+	s := g.Players[id].snake
+	head := s.head()
+	fx, fy := s.getDir().vector()
+	// right of the heading
+	rx, ry := -fy, fx
+	rays := [8][2]int{
+		{fx, fy},
+		{fx + rx, fy + ry},
+		{rx, ry},
+		{rx - fx, ry - fy},
+		{-fx, -fy},
+		{-fx - rx, -fy - ry},
+		{-rx, -ry},
+		{fx - rx, fy - ry},
+	}
+
+	in := make([]float64, 0, 8*4)
+	for _, ray := range rays {
+		var wallD, foodD, selfD, enemyD int
+		for d := 1; ; d++ {
+			x, y := head.x+ray[0]*d, head.y+ray[1]*d
+			if y < 0 || y >= len(g.board) || x < 0 || x >= len(g.board[y]) {
+				if wallD == 0 {
+					wallD = d
+				}
+				break
+			}
+			switch cell := g.board[y][x]; {
+			case cell == wall:
+				if wallD == 0 {
+					wallD = d
+				}
+			case cell == food:
+				if foodD == 0 {
+					foodD = d
+				}
+			case ID(cell) == id:
+				if selfD == 0 {
+					selfD = d
+				}
+			case cell > wall:
+				if enemyD == 0 {
+					enemyD = d
+				}
+			}
+		}
+		in = append(in, inverse(wallD), inverse(foodD), inverse(selfD), inverse(enemyD))
+	}
+	return in
+}
+
+// inverse returns 1/d, or 0 for a distance of 0 which means nothing was seen
+func inverse(d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return 1 / float64(d)
+}
@@ -0,0 +1,55 @@
+package snake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRaycastVision(t *testing.T) {
+	/*
+		#########
+		#.......#
+		#...F...#
+		#.......#
+		#...2.33#  snake 2 heads north from (4,5) to (4,4)
+		#...2...#
+		#########
+	*/
+	g := &Game{board: newBoard(7, 9), Players: map[ID]playerInfo{
+		2: {snake: &snake{position: []Position{{x: 4, y: 5}, {x: 4, y: 4}}}},
+		3: {snake: &snake{position: []Position{{x: 7, y: 4}, {x: 6, y: 4}}}},
+	}}
+	g.board[2][4] = food
+	g.board[5][4], g.board[4][4] = 2, 2
+	g.board[4][7], g.board[4][6] = 3, 3
+
+	in := RaycastVision{}.See(g, 2)
+	require.Len(t, in, RaycastVision{}.Size())
+	ray := func(i int) []float64 { return in[i*4 : i*4+4] }
+
+	// straight ahead: food at 2, wall at 4
+	require.Equal(t, []float64{1.0 / 4, 1.0 / 2, 0, 0}, ray(0))
+	// ahead right: wall at 4
+	require.Equal(t, []float64{1.0 / 4, 0, 0, 0}, ray(1))
+	// right: snake 3 at 2, wall at 4
+	require.Equal(t, []float64{1.0 / 4, 0, 0, 1.0 / 2}, ray(2))
+	// behind right: wall at 2
+	require.Equal(t, []float64{1.0 / 2, 0, 0, 0}, ray(3))
+	// behind: own body at 1, wall at 2
+	require.Equal(t, []float64{1.0 / 2, 0, 1, 0}, ray(4))
+	// left: wall at 4
+	require.Equal(t, []float64{1.0 / 4, 0, 0, 0}, ray(6))
+}
+
+func TestRaycastVisionTurns(t *testing.T) {
+	// heading east the ray to the right points south
+	g := &Game{board: newBoard(7, 9), Players: map[ID]playerInfo{
+		2: {snake: &snake{position: []Position{{x: 3, y: 2}, {x: 4, y: 2}}}},
+	}}
+	g.board[2][3], g.board[2][4] = 2, 2
+	g.board[5][4] = food
+	in := RaycastVision{}.See(g, 2)
+	require.Equal(t, []float64{1.0 / 4, 1.0 / 3, 0, 0}, in[2*4:2*4+4])
+	require.Equal(t, []float64{1.0 / 2, 0, 0, 0}, in[6*4:6*4+4])
+}
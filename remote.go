@@ -0,0 +1,300 @@
+package snake
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+)
+
+// A remote player talks to the game over a connection with json lines.
+// Every tick the game sends a RemoteState and the player answers with a RemoteMove
+// for that tick. Moves for older ticks are skipped, so a player that answers too
+// late doesn't fall behind
+
+// RemoteState is the state the game sends to a remote player every tick,
+// PlayRemote hands it to a Player as its GameState
+type RemoteState struct {
+	ID        ID          `json:"id"`
+	Tick      int         `json:"tick"`
+	Life      float64     `json:"life"`
+	Inputs    []float64   `json:"vision"`
+	Sensor    []int8      `json:"sensor"`
+	Board     Board       `json:"board"`
+	Snake     SnakeInfo   `json:"snake"`
+	Opponents []SnakeInfo `json:"opponents"`
+	Food      []Position  `json:"food"`
+	Height    int         `json:"height"`
+	Width     int         `json:"width"`
+}
+
+// RemoteMove is the answer of a remote player, Move is l for left, s for straight or r for right
+type RemoteMove struct {
+	Tick int    `json:"tick"`
+	Move string `json:"move"`
+}
+
+func newRemoteState(gs GameState, id ID) RemoteState {
+	s := RemoteState{
+		ID:        id,
+		Tick:      gs.Tick(),
+		Life:      gs.Life(id),
+		Inputs:    gs.Inputs(id),
+		Sensor:    gs.Vision(id),
+		Board:     gs.Board(),
+		Opponents: gs.Opponents(id),
+		Food:      gs.Food(),
+		Height:    gs.Height(),
+		Width:     gs.Width(),
+	}
+	s.Snake, _ = gs.Snake(id)
+	return s
+}
+
+// letterMove returns the move for l, s or r, anything else goes straight
+func letterMove(letter string, id ID) Move {
+	switch letter {
+	case "l":
+		return Move{Move: []float64{1, 0, 0}, ID: id}
+	case "r":
+		return Move{Move: []float64{0, 0, 1}, ID: id}
+	default:
+		return Move{Move: []float64{0, 1, 0}, ID: id}
+	}
+}
+
+// lines sends states and reads moves as json lines
+type lines struct {
+	enc *json.Encoder
+	r   *bufio.Reader
+	// line holds the start of a move cut off by a deadline
+	line []byte
+	// setDeadline makes reads and writes fail after t, the zero time waits forever
+	setDeadline func(t time.Time) error
+}
+
+func newLines(r io.Reader, w io.Writer, setDeadline func(time.Time) error) *lines {
+	return &lines{
+		enc:         json.NewEncoder(w),
+		r:           bufio.NewReader(r),
+		setDeadline: setDeadline,
+	}
+}
+
+// play sends the state and waits for the move of its tick, it gives up after
+// the timeout or once ctx is done. ErrMoveTimeout is returned for the timeout
+func (l *lines) play(ctx context.Context, state RemoteState, timeout time.Duration) (Move, error) {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	if d, ok := ctx.Deadline(); ok && (deadline.IsZero() || d.Before(deadline)) {
+		deadline = d
+	}
+	if err := l.setDeadline(deadline); err != nil {
+		return Move{}, err
+	}
+	stop := context.AfterFunc(ctx, func() {
+		l.setDeadline(time.Now())
+	})
+	defer stop()
+
+	if err := l.enc.Encode(state); err != nil {
+		return Move{}, l.timeout(ctx, err)
+	}
+	for {
+		m, err := l.readMove()
+		if err != nil {
+			return Move{}, l.timeout(ctx, err)
+		}
+		if m.Tick == state.Tick {
+			return letterMove(m.Move, state.ID), nil
+		}
+	}
+}
+
+// timeout turns errors of a passed deadline into ErrMoveTimeout unless ctx is done
+func (l *lines) timeout(ctx context.Context, err error) error {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() && ctx.Err() == nil {
+		return ErrMoveTimeout
+	}
+	return err
+}
+
+// readMove reads the next line, a line that is cut off by a deadline
+// is finished by the next call
+func (l *lines) readMove() (RemoteMove, error) {
+	line, err := l.r.ReadBytes('\n')
+	l.line = append(l.line, line...)
+	if err != nil {
+		return RemoteMove{}, err
+	}
+	var m RemoteMove
+	err = json.Unmarshal(l.line, &m)
+	l.line = l.line[:0]
+	return m, err
+}
+
+// RemotePlayer is a player on the other end of a connection.
+// Connection errors make it panic, the game handles that with its FaultPolicy
+type RemotePlayer struct {
+	ID ID
+	// Timeout is how long the remote player gets for a move, 0 waits forever
+	Timeout time.Duration
+	// Vision is sent to the remote player as its vision, nil uses the game's vision
+	Vision VisionProvider
+
+	conn  net.Conn
+	lines *lines
+}
+
+// NewRemotePlayer returns a player that plays over conn
+func NewRemotePlayer(conn net.Conn) *RemotePlayer {
+	return &RemotePlayer{
+		conn:  conn,
+		lines: newLines(conn, conn, conn.SetDeadline),
+	}
+}
+
+// DialPlayer connects to a remote player listening on addr
+func DialPlayer(addr string) (*RemotePlayer, error) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return NewRemotePlayer(conn), nil
+}
+
+// AcceptPlayer waits for a remote player to connect to l
+func AcceptPlayer(l net.Listener) (*RemotePlayer, error) {
+	conn, err := l.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return NewRemotePlayer(conn), nil
+}
+
+// Play implements Player
+func (p *RemotePlayer) Play(gameState GameState) Move {
+	return p.PlayContext(context.Background(), gameState)
+}
+
+// PlayContext implements ContextPlayer, the move is abandoned when ctx is done
+func (p *RemotePlayer) PlayContext(ctx context.Context, gameState GameState) Move {
+	m, err := p.lines.play(ctx, newRemoteState(gameState, p.ID), p.Timeout)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// SetID implements Player
+func (p *RemotePlayer) SetID(id ID) {
+	p.ID = id
+}
+
+// VisionProvider implements VisionPlayer
+func (p *RemotePlayer) VisionProvider() VisionProvider {
+	return p.Vision
+}
+
+// Close closes the connection to the remote player
+func (p *RemotePlayer) Close() error {
+	return p.conn.Close()
+}
+
+// PlayRemote is the other end of a RemotePlayer, p plays the states read from conn.
+// It returns nil when the game closes the connection
+func PlayRemote(ctx context.Context, conn net.Conn, p Player) error {
+	stop := context.AfterFunc(ctx, func() {
+		conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	err := playLines(conn, conn, p)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
+
+// playLines plays p with the states read from r and writes its moves to w
+// until r is closed, a line that is not a game state is an error
+func playLines(r io.Reader, w io.Writer, p Player) error {
+	enc := json.NewEncoder(w)
+	dec := json.NewDecoder(bufio.NewReader(r))
+	id := ID(0)
+	for {
+		var (
+			s    RemoteState
+			line json.RawMessage
+		)
+		err := dec.Decode(&line)
+		if err == nil {
+			err = json.Unmarshal(line, &s)
+		}
+		// anything else, like the error a server answers a refused request with, ends the game
+		if err == nil && (s.ID == 0 || s.Board == nil) {
+			return fmt.Errorf("not a game state: %s", line)
+		}
+		if err == nil {
+			// a new game starts at tick 0
+			if s.ID != id || s.Tick == 0 {
+				id = s.ID
+				p.SetID(id)
+			}
+			m := p.Play(remoteView{&s})
+			err = enc.Encode(RemoteMove{Tick: s.Tick, Move: string(m.getChoice())[:1]})
+		}
+		switch {
+		case err == nil:
+		case errors.Is(err, io.EOF):
+			return nil
+		default:
+			return fmt.Errorf("tick %d: %w", s.Tick, err)
+		}
+	}
+}
+
+// remoteView is the GameState of a RemoteState
+type remoteView struct {
+	s *RemoteState
+}
+
+func (v remoteView) Vision(id ID) []int8 { return v.s.Sensor }
+
+func (v remoteView) Inputs(id ID) []float64 { return v.s.Inputs }
+
+// Window is not sent to remote players, it is always nil
+func (v remoteView) Window(id ID, side int) []float64 { return nil }
+
+func (v remoteView) Life(id ID) float64 { return v.s.Life }
+
+func (v remoteView) Board() Board { return v.s.Board }
+
+func (v remoteView) Snake(id ID) (SnakeInfo, bool) {
+	if id == v.s.ID {
+		return v.s.Snake, true
+	}
+	for _, o := range v.s.Opponents {
+		if o.ID == id {
+			return o, true
+		}
+	}
+	return SnakeInfo{}, false
+}
+
+func (v remoteView) Opponents(id ID) []SnakeInfo { return v.s.Opponents }
+
+func (v remoteView) Food() []Position { return v.s.Food }
+
+func (v remoteView) Tick() int { return v.s.Tick }
+
+func (v remoteView) Height() int { return v.s.Height }
+
+func (v remoteView) Width() int { return v.s.Width }
@@ -0,0 +1,128 @@
+package snake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// listen returns a remote player connected over loopback to the conn returned
+func listen(t *testing.T) (*RemotePlayer, net.Conn) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	require.NoError(t, err)
+	p, err := AcceptPlayer(l)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		p.Close()
+		conn.Close()
+	})
+	return p, conn
+}
+
+// playBoards plays a seeded game of players for 20 ticks and returns the board after every tick,
+// the first player must not fault
+func playBoards(t *testing.T, players []Player) []Board {
+	g, err := NewGameWithConfig(GameConfig{
+		Height:      15,
+		Width:       15,
+		Players:     players,
+		NbFoodOnMap: 5,
+		Seed:        1,
+	})
+	require.NoError(t, err)
+	var boards []Board
+	for i := 0; i < 20; i++ {
+		gameOver, _ := g.PlayRound()
+		require.Empty(t, g.Faults(2))
+		boards = append(boards, g.Board())
+		if gameOver {
+			break
+		}
+	}
+	return boards
+}
+
+func TestRemotePlayer(t *testing.T) {
+	remote, conn := listen(t)
+	done := make(chan error)
+	go func() {
+		done <- PlayRemote(context.Background(), conn, &straightPlayer{})
+	}()
+
+	require.Equal(t, playBoards(t, []Player{&straightPlayer{}, &straightPlayer{}}), playBoards(t, []Player{remote, &straightPlayer{}}))
+
+	// the client is done once the game hangs up
+	remote.Close()
+	require.NoError(t, <-done)
+}
+
+// silentPlayer reads the states but never answers
+func silentPlayer(conn net.Conn) {
+	buf := make([]byte, 1024)
+	for {
+		if _, err := conn.Read(buf); err != nil {
+			return
+		}
+	}
+}
+
+func TestRemotePlayerTimeout(t *testing.T) {
+	remote, conn := listen(t)
+	remote.Timeout = 20 * time.Millisecond
+	go silentPlayer(conn)
+
+	g, err := NewGameWithConfig(GameConfig{Height: 10, Width: 10, Players: []Player{remote}, Seed: 1})
+	require.NoError(t, err)
+	g.PlayRound()
+	faults := g.Faults(2)
+	require.Len(t, faults, 1)
+	require.Contains(t, faults[0].Err.Error(), ErrMoveTimeout.Error())
+
+	// a cancelled run stops waiting on the player
+	ctx, cancel := context.WithCancel(context.Background())
+	remote.Timeout = 0
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+	_, err = g.Run(ctx, RunOptions{})
+	require.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestRemotePlayerLateMove(t *testing.T) {
+	remote, conn := listen(t)
+	go func() {
+		// an answer for an old tick is skipped
+		enc := json.NewEncoder(conn)
+		dec := json.NewDecoder(conn)
+		var s RemoteState
+		if dec.Decode(&s) != nil {
+			return
+		}
+		enc.Encode(RemoteMove{Tick: s.Tick - 1, Move: "l"})
+		enc.Encode(RemoteMove{Tick: s.Tick, Move: "r"})
+	}()
+
+	g, err := NewGameWithConfig(GameConfig{Height: 10, Width: 10, Players: []Player{remote}, Seed: 1})
+	require.NoError(t, err)
+	g.tick = 3
+	require.Equal(t, []float64{0, 0, 1}, remote.Play(g).Move)
+}
+
+func TestPlayLinesNoState(t *testing.T) {
+	err := playLines(strings.NewReader(`{"error":"lobby \"lunch\" is full"}`+"\n"), io.Discard, &straightPlayer{})
+	require.ErrorContains(t, err, `lobby \"lunch\" is full`)
+	require.Error(t, playLines(strings.NewReader("{}\n"), io.Discard, &straightPlayer{}))
+	require.NoError(t, playLines(strings.NewReader(""), io.Discard, &straightPlayer{}))
+}
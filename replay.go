@@ -0,0 +1,200 @@
+package snake
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ReplayVersion is the version of the replay format written by the Recorder.
+// Version 2 places snakes and food on free cells, version 1 games play out differently
+const ReplayVersion = 2
+
+// A replay is stored as json lines, the first line is the ReplayHeader
+// and every following line is the ReplayTick of one PlayRound
+
+// ReplayHeader holds the settings needed to recreate the game
+type ReplayHeader struct {
+	Version     int            `json:"version"`
+	Seed        int64          `json:"seed"`
+	Height      int            `json:"height"`
+	Width       int            `json:"width"`
+	NbFoodOnMap int            `json:"nbFood"`
+	Resolution  Resolution     `json:"resolution"`
+	FaultPolicy FaultPolicy    `json:"faultPolicy,omitempty"`
+	Players     []ReplayPlayer `json:"players"`
+	// Food holds the food placed when the game was created as x, y pairs
+	Food [][2]int `json:"food,omitempty"`
+}
+
+// ReplayPlayer describes a player of a recorded game
+type ReplayPlayer struct {
+	ID   ID     `json:"id"`
+	Name string `json:"name"`
+}
+
+// ReplayTick holds the moves played during a tick and the food placed during that tick
+type ReplayTick struct {
+	// Moves holds the choice of every player, l for left, s for straight and r for right
+	Moves map[ID]string `json:"m"`
+	Food  [][2]int      `json:"f,omitempty"`
+	// Faults holds the players that failed to give a move
+	Faults []ID `json:"x,omitempty"`
+}
+
+// Replay is a recorded game
+type Replay struct {
+	ReplayHeader
+	Ticks []ReplayTick
+}
+
+// Recorder writes the game it is handed to through GameConfig to w
+// The game must be created with a Seed, NewGameWithConfig refuses a Recorder together with a Rand
+type Recorder struct {
+	enc  *json.Encoder
+	tick ReplayTick
+	food [][2]int
+	err  error
+}
+
+// NewRecorder returns a recorder writing to w
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{enc: json.NewEncoder(w)}
+}
+
+// Err returns the first error encountered while writing
+func (r *Recorder) Err() error {
+	return r.err
+}
+
+func (r *Recorder) write(v interface{}) {
+	if r.err != nil {
+		return
+	}
+	r.err = r.enc.Encode(v)
+}
+
+func (r *Recorder) start(cfg GameConfig) {
+	h := ReplayHeader{
+		Version:     ReplayVersion,
+		Seed:        cfg.Seed,
+		Height:      cfg.Height,
+		Width:       cfg.Width,
+		NbFoodOnMap: cfg.NbFoodOnMap,
+		Resolution:  cfg.Resolution,
+		FaultPolicy: cfg.FaultPolicy,
+		Food:        r.food,
+	}
+	for i, p := range cfg.Players {
+		h.Players = append(h.Players, ReplayPlayer{ID: ID(i + 2), Name: fmt.Sprintf("%T", p)})
+	}
+	r.food = nil
+	r.write(h)
+}
+
+func (r *Recorder) spawnedFood(pos Position) {
+	r.food = append(r.food, [2]int{pos.x, pos.y})
+}
+
+func (r *Recorder) fault(id ID) {
+	r.tick.Faults = append(r.tick.Faults, id)
+}
+
+func (r *Recorder) moves(moves []Move) {
+	r.tick.Moves = make(map[ID]string, len(moves))
+	for _, m := range moves {
+		r.tick.Moves[m.ID] = string(m.getChoice())[:1]
+	}
+}
+
+func (r *Recorder) endTick() {
+	r.tick.Food = r.food
+	r.write(r.tick)
+	r.tick = ReplayTick{}
+	r.food = nil
+}
+
+// LoadReplay reads a replay written by a Recorder
+func LoadReplay(r io.Reader) (*Replay, error) {
+	dec := json.NewDecoder(bufio.NewReader(r))
+	var replay Replay
+	if err := dec.Decode(&replay.ReplayHeader); err != nil {
+		return nil, fmt.Errorf("reading replay header: %v", err)
+	}
+	if replay.Version != ReplayVersion {
+		return nil, fmt.Errorf("unsupported replay version %d", replay.Version)
+	}
+	for {
+		var t ReplayTick
+		err := dec.Decode(&t)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading replay tick %d: %v", len(replay.Ticks), err)
+		}
+		replay.Ticks = append(replay.Ticks, t)
+	}
+	return &replay, nil
+}
+
+// Game creates a game in which the players play the recorded moves.
+// Each call to PlayRound plays the next recorded tick, once all ticks are played
+// the players keep going straight
+func (r *Replay) Game() (*Game, error) {
+	if len(r.Players) == 0 {
+		return nil, errors.New("replay has no players")
+	}
+	players := make([]Player, len(r.Players))
+	replayers := make([]*replayPlayer, len(r.Players))
+	for i := range r.Players {
+		replayers[i] = &replayPlayer{replay: r}
+		players[i] = replayers[i]
+	}
+	g, err := NewGameWithConfig(GameConfig{
+		Height:      r.Height,
+		Width:       r.Width,
+		Players:     players,
+		NbFoodOnMap: r.NbFoodOnMap,
+		Seed:        r.Seed,
+		Resolution:  r.Resolution,
+		FaultPolicy: r.FaultPolicy,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range replayers {
+		p.game = g
+	}
+	return g, nil
+}
+
+// errReplayedFault is raised by replay players in the ticks the recorded player failed,
+// the game handles it like the original fault
+var errReplayedFault = errors.New("recorded fault")
+
+// replayPlayer plays the moves of a recorded player
+type replayPlayer struct {
+	ID     ID
+	replay *Replay
+	game   *Game
+}
+
+func (p *replayPlayer) Play(gameState GameState) Move {
+	t := p.game.Tick()
+	if t >= len(p.replay.Ticks) {
+		return Move{Move: []float64{0, 1, 0}, ID: p.ID}
+	}
+	for _, id := range p.replay.Ticks[t].Faults {
+		if id == p.ID {
+			panic(errReplayedFault)
+		}
+	}
+	return letterMove(p.replay.Ticks[t].Moves[p.ID], p.ID)
+}
+
+func (p *replayPlayer) SetID(id ID) {
+	p.ID = id
+}
@@ -0,0 +1,63 @@
+package snake
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplay(t *testing.T) {
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf)
+	g, err := NewGameWithConfig(GameConfig{
+		Height:      20,
+		Width:       20,
+		Players:     []Player{&Random{}, &Random{}, &Random{}},
+		NbFoodOnMap: 10,
+		Seed:        7,
+		Resolution:  ResolveHeadOnKillsBoth,
+		Recorder:    rec,
+	})
+	require.NoError(t, err)
+	boards := []Board{g.Board()}
+	for i := 0; i < 200; i++ {
+		gameOver, _ := g.PlayRound()
+		boards = append(boards, g.Board())
+		if gameOver {
+			break
+		}
+	}
+	require.NoError(t, rec.Err())
+
+	replay, err := LoadReplay(&buf)
+	require.NoError(t, err)
+	require.Equal(t, ReplayVersion, replay.Version)
+	require.Len(t, replay.Players, 3)
+	require.Equal(t, len(boards)-1, len(replay.Ticks))
+
+	rg, err := replay.Game()
+	require.NoError(t, err)
+	require.Equal(t, boards[0], rg.Board())
+	for i := range replay.Ticks {
+		rg.PlayRound()
+		require.Equal(t, boards[i+1], rg.Board(), "tick %d", i)
+	}
+}
+
+func TestLoadReplayVersion(t *testing.T) {
+	_, err := LoadReplay(bytes.NewBufferString(`{"version":999}`))
+	require.Error(t, err)
+}
+
+func TestRecorderRand(t *testing.T) {
+	_, err := NewGameWithConfig(GameConfig{
+		Height:   10,
+		Width:    10,
+		Players:  []Player{&Random{}},
+		Rand:     rand.New(rand.NewSource(1)),
+		Recorder: NewRecorder(&bytes.Buffer{}),
+	})
+	require.Error(t, err)
+}
@@ -0,0 +1,158 @@
+package snake
+
+// Resolution is the rule used to settle conflicts between the moves of one tick
+type Resolution int
+
+const (
+	// ResolveByID applies the moves one by one in ID order, lower IDs move first
+	ResolveByID Resolution = iota
+	// ResolveHeadOnKillsBoth moves all snakes at once, snakes moving into the same cell all die
+	ResolveHeadOnKillsBoth
+	// ResolveLongerWins moves all snakes at once, the longest snake moving into a cell
+	// survives and the others die. When the longest snakes are equal they all die
+	ResolveLongerWins
+)
+
+// playSequential applies the moves in order, a later move sees the board left by the previous ones
+func (g *Game) playSequential(moves []Move) {
+	for _, move := range moves {
+		if _, ok := g.Players[move.ID]; !ok {
+			continue
+		}
+		if dead := g.PlayMove(move); dead {
+			g.removePlayer(move.ID)
+		}
+	}
+}
+
+// playSimultaneous computes all new heads on the board as it was before the tick
+// and only then applies the moves, so the order of the moves doesn't matter
+func (g *Game) playSimultaneous(moves []Move) {
+	targets := make(map[ID]Position, len(moves))
+	eats := make(map[ID]bool, len(moves))
+	vacated := make(map[Position]bool, len(moves))
+	contenders := make(map[Position][]ID, len(moves))
+	for _, m := range moves {
+		p := g.Players[m.ID]
+		pos := p.snake.newHeadPos(m)
+		targets[m.ID] = pos
+		eats[m.ID] = g.board[pos.y][pos.x] == food
+		if !eats[m.ID] {
+			vacated[p.snake.tail()] = true
+		}
+		contenders[pos] = append(contenders[pos], m.ID)
+	}
+
+	var survivors []ID
+	type death struct {
+		id     ID
+		cause  Cause
+		killer ID
+	}
+	var deaths []death
+	for _, m := range moves {
+		pos := targets[m.ID]
+		cell := g.board[pos.y][pos.x]
+		if cell != empty && cell != food && !vacated[pos] {
+			cause, killer := g.collision(m.ID, pos)
+			deaths = append(deaths, death{m.ID, cause, killer})
+			continue
+		}
+		if !g.winsCell(m.ID, contenders[pos]) {
+			deaths = append(deaths, death{m.ID, HeadOn, g.headOnKiller(m.ID, contenders[pos])})
+			continue
+		}
+		survivors = append(survivors, m.ID)
+	}
+	for _, d := range deaths {
+		g.died(d.id, d.cause, d.killer)
+	}
+
+	// remove the dead first so the cells they leave can be taken
+	alive := make(map[ID]bool, len(survivors))
+	for _, id := range survivors {
+		alive[id] = true
+	}
+	for _, m := range moves {
+		if !alive[m.ID] {
+			g.removePlayer(m.ID)
+		}
+	}
+
+	// clear the tails before placing the heads, a head may take the cell of a tail
+	for _, id := range survivors {
+		if !eats[id] {
+			t := g.Players[id].snake.tail()
+			g.board[t.y][t.x] = empty
+		}
+	}
+
+	var starved []ID
+	for _, id := range survivors {
+		p := g.Players[id]
+		pos := targets[id]
+		p.snake.moveTo(pos, eats[id])
+		g.board[pos.y][pos.x] = int8(id)
+		g.emit(Event{Type: Moved, ID: id})
+		if !eats[id] {
+			if dead := g.reduceLife(id); dead {
+				starved = append(starved, id)
+			}
+			continue
+		}
+		g.restoreLife(id)
+		p = g.Players[id]
+		if p.maxLen < len(p.snake.position) {
+			p.maxLen = len(p.snake.position)
+		}
+		g.Players[id] = p
+		g.emit(Event{Type: AteFood, ID: id})
+	}
+	for _, id := range starved {
+		g.removePlayer(id)
+	}
+
+	// only place new food once all heads are on the board
+	for _, id := range survivors {
+		if eats[id] {
+			g.newFood()
+		}
+	}
+}
+
+// winsCell reports if the player gets the cell all contenders are moving into
+func (g *Game) winsCell(id ID, contenders []ID) bool {
+	if len(contenders) == 1 {
+		return true
+	}
+	if g.resolution != ResolveLongerWins {
+		return false
+	}
+	for _, c := range contenders {
+		if c != id && g.PlayerLen(c) >= g.PlayerLen(id) {
+			return false
+		}
+	}
+	return true
+}
+
+// headOnKiller returns the contender that won the cell, or the longest other contender when nobody did
+func (g *Game) headOnKiller(id ID, contenders []ID) ID {
+	var killer ID
+	for _, c := range contenders {
+		if c == id {
+			continue
+		}
+		if killer == 0 || g.PlayerLen(c) > g.PlayerLen(killer) {
+			killer = c
+		}
+	}
+	return killer
+}
+
+func (g *Game) removePlayer(id ID) {
+	for _, pos := range g.Players[id].position {
+		g.board[pos.y][pos.x] = empty
+	}
+	delete(g.Players, id)
+}
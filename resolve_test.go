@@ -0,0 +1,99 @@
+package snake
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newHeadOnGame returns a game with two snakes facing each other with one empty cell between them
+func newHeadOnGame(res Resolution, westLen int) *Game {
+	g := &Game{
+		board:      newBoard(7, 11),
+		Players:    make(map[ID]playerInfo),
+		rnd:        rand.New(rand.NewSource(1)),
+		resolution: res,
+		deaths:     make(map[ID]DeathReport),
+	}
+	var west []Position
+	for x := 5 - westLen; x < 5; x++ {
+		west = append(west, Position{x: x, y: 3})
+	}
+	snakes := map[ID][]Position{
+		2: west,
+		3: {{x: 8, y: 3}, {x: 7, y: 3}, {x: 6, y: 3}},
+	}
+	for id, pos := range snakes {
+		for _, p := range pos {
+			g.board[p.y][p.x] = int8(id)
+		}
+		g.Players[id] = playerInfo{
+			Player: &straightPlayer{ID: id},
+			snake:  &snake{position: pos},
+			life:   1,
+		}
+	}
+	return g
+}
+
+func TestResolveHeadOn(t *testing.T) {
+	g := newHeadOnGame(ResolveHeadOnKillsBoth, 3)
+	gameOver, _ := g.PlayRound()
+	require.True(t, gameOver)
+	require.False(t, g.Alive(2))
+	require.False(t, g.Alive(3))
+}
+
+func TestResolveLongerWins(t *testing.T) {
+	g := newHeadOnGame(ResolveLongerWins, 4)
+	gameOver, _ := g.PlayRound()
+	require.False(t, gameOver)
+	require.True(t, g.Alive(2))
+	require.False(t, g.Alive(3))
+	require.Equal(t, int8(2), g.board[3][5])
+
+	g = newHeadOnGame(ResolveLongerWins, 3)
+	gameOver, _ = g.PlayRound()
+	require.True(t, gameOver)
+}
+
+func TestResolveByID(t *testing.T) {
+	g := newHeadOnGame(ResolveByID, 3)
+	gameOver, _ := g.PlayRound()
+	require.False(t, gameOver)
+	require.True(t, g.Alive(2))
+	require.False(t, g.Alive(3))
+}
+
+func TestResolveFollowTail(t *testing.T) {
+	// snake 3 moves into the cell snake 2's tail leaves in the same tick
+	g := &Game{
+		board:      newBoard(7, 11),
+		Players:    make(map[ID]playerInfo),
+		rnd:        rand.New(rand.NewSource(1)),
+		resolution: ResolveHeadOnKillsBoth,
+		deaths:     make(map[ID]DeathReport),
+	}
+	snakes := map[ID][]Position{
+		2: {{x: 4, y: 3}, {x: 5, y: 3}},
+		3: {{x: 2, y: 3}, {x: 3, y: 3}},
+	}
+	for id, pos := range snakes {
+		for _, p := range pos {
+			g.board[p.y][p.x] = int8(id)
+		}
+		g.Players[id] = playerInfo{
+			Player: &straightPlayer{ID: id},
+			snake:  &snake{position: pos},
+			life:   1,
+		}
+	}
+	gameOver, _ := g.PlayRound()
+	require.False(t, gameOver)
+	require.True(t, g.Alive(2))
+	require.True(t, g.Alive(3))
+	require.Equal(t, int8(3), g.board[3][4])
+	require.Equal(t, int8(2), g.board[3][6])
+	require.Equal(t, int8(empty), g.board[3][2])
+}
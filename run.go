@@ -0,0 +1,69 @@
+package snake
+
+import (
+	"context"
+	"sort"
+)
+
+// ContextPlayer is a player that stops thinking when the context of Run is cancelled.
+// The game calls PlayContext instead of Play for these players
+type ContextPlayer interface {
+	Player
+	PlayContext(ctx context.Context, gameState GameState) Move
+}
+
+// RunOptions change how Run drives the game
+type RunOptions struct {
+	// MaxTicks stops Run after that many ticks, 0 runs until the game is over
+	MaxTicks int
+	// OnTick is called with a copy of the board after every tick
+	OnTick func(Board)
+}
+
+// Result is the state of the game when Run returns
+type Result struct {
+	// Ticks is the number of ticks the game played, including the ones played before Run
+	Ticks    int
+	GameOver bool
+	// Full is set when the game ended because the snakes cover the whole board
+	Full bool
+	// Alive holds the players still in the game in ID order
+	Alive  []ID
+	Deaths map[ID]DeathReport
+}
+
+// Run plays rounds until the game is over, MaxTicks rounds are played or ctx is done.
+// The error is the context's error when Run stopped because of it
+func (g *Game) Run(ctx context.Context, opts RunOptions) (Result, error) {
+	g.ctx = ctx
+	defer func() { g.ctx = nil }()
+
+	start := g.tick
+	for opts.MaxTicks <= 0 || g.tick-start < opts.MaxTicks {
+		if err := ctx.Err(); err != nil {
+			return g.result(false), err
+		}
+		gameOver, _ := g.PlayRound()
+		if opts.OnTick != nil {
+			opts.OnTick(g.Board())
+		}
+		if gameOver {
+			return g.result(true), nil
+		}
+	}
+	return g.result(false), nil
+}
+
+func (g *Game) result(gameOver bool) Result {
+	r := Result{
+		Ticks:    g.tick,
+		GameOver: gameOver,
+		Full:     g.Full(),
+		Deaths:   g.Deaths(),
+	}
+	for id := range g.Players {
+		r.Alive = append(r.Alive, id)
+	}
+	sort.Slice(r.Alive, func(i, j int) bool { return r.Alive[i] < r.Alive[j] })
+	return r
+}
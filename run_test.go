@@ -0,0 +1,73 @@
+package snake
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// waitingPlayer blocks every move until the context is done
+type waitingPlayer struct {
+	ID ID
+}
+
+func (w *waitingPlayer) Play(gameState GameState) Move {
+	return w.PlayContext(context.Background(), gameState)
+}
+
+func (w *waitingPlayer) PlayContext(ctx context.Context, gameState GameState) Move {
+	<-ctx.Done()
+	return Move{Move: []float64{0, 1, 0}, ID: w.ID}
+}
+
+func (w *waitingPlayer) SetID(id ID) {
+	w.ID = id
+}
+
+func TestRunGameOver(t *testing.T) {
+	g := newHeadOnGame(ResolveHeadOnKillsBoth, 3)
+	var boards []Board
+	res, err := g.Run(context.Background(), RunOptions{OnTick: func(b Board) {
+		boards = append(boards, b)
+	}})
+	require.NoError(t, err)
+	require.True(t, res.GameOver)
+	require.Equal(t, 1, res.Ticks)
+	require.Empty(t, res.Alive)
+	require.Len(t, res.Deaths, 2)
+	require.Len(t, boards, 1)
+}
+
+func TestRunMaxTicks(t *testing.T) {
+	// the snake of seed 1 goes straight for five ticks without reaching a wall
+	g, err := NewGameWithConfig(GameConfig{Height: 30, Width: 30, Players: []Player{&straightPlayer{}}, Seed: 1})
+	require.NoError(t, err)
+	res, err := g.Run(context.Background(), RunOptions{MaxTicks: 3})
+	require.NoError(t, err)
+	require.Equal(t, 3, res.Ticks)
+	require.False(t, res.GameOver)
+	require.Equal(t, []ID{2}, res.Alive)
+
+	// MaxTicks counts from the tick Run starts at
+	res, err = g.Run(context.Background(), RunOptions{MaxTicks: 2})
+	require.NoError(t, err)
+	require.Equal(t, 5, res.Ticks)
+	require.False(t, res.GameOver)
+	require.Equal(t, []ID{2}, res.Alive)
+}
+
+func TestRunCancel(t *testing.T) {
+	g, err := NewGameWithConfig(GameConfig{Height: 10, Width: 10, Players: []Player{&waitingPlayer{}}, Seed: 1})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	res, err := g.Run(ctx, RunOptions{})
+	require.Equal(t, context.Canceled, err)
+	require.False(t, res.GameOver)
+	require.Equal(t, 1, res.Ticks)
+}
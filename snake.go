@@ -2,46 +2,36 @@ package snake
 
 import (
 	"math/rand"
-	"time"
 )
 
 type snake struct {
 	position []Position
 }
 
-func randomPos(width, height int) Position {
-	return Position{x: rand.Intn(width-2) + 1, y: rand.Intn(height-2) + 1}
-}
-
-func randomDir() (int, int) {
-	dir := rand.Int() % 4
-	if dir == 0 {
-		return -1, 0
-	} else if dir == 1 {
-		return 1, 0
-	} else if dir == 2 {
-		return 0, -1
-	} else {
-		return 0, 1
-	}
-}
-
-func newSnake(board Board, id ID) *snake {
-	rand.Seed(time.Now().UnixNano())
-	s := snake{position: make([]Position, 2)}
-	diry, dirx := randomDir()
-	for {
-		pos := randomPos(len(board[0]), len(board))
-		x := pos.x
-		y := pos.y
-		if board[y][x] == empty && board[y+diry][x+dirx] == empty {
-			board[y][x] = int8(id)
-			board[y+diry][x+dirx] = int8(id)
-			s.position[0].x, s.position[0].y = x, y
-			s.position[1].x, s.position[1].y = x+dirx, y+diry
-			return &s
+// newSnake places a snake of two cells on a random pair of free neighbouring cells
+func newSnake(board Board, id ID, rnd *rand.Rand) (*snake, error) {
+	var spots [][2]Position
+	for y := range board {
+		for x := range board[y] {
+			if board[y][x] != empty {
+				continue
+			}
+			for _, d := range []direction{west, east, north, south} {
+				dx, dy := d.vector()
+				if board[y+dy][x+dx] == empty {
+					spots = append(spots, [2]Position{{x: x, y: y}, {x: x + dx, y: y + dy}})
+				}
+			}
 		}
 	}
+	if len(spots) == 0 {
+		return nil, ErrBoardFull
+	}
+	spot := spots[rnd.Intn(len(spots))]
+	for _, pos := range spot {
+		board[pos.y][pos.x] = int8(id)
+	}
+	return &snake{position: spot[:]}, nil
 }
 
 type direction string
@@ -53,6 +43,19 @@ const (
 	east  direction = "east"
 )
 
+// vector returns the step on the board going in direction d
+func (d direction) vector() (dx, dy int) {
+	switch d {
+	case north:
+		return 0, -1
+	case south:
+		return 0, 1
+	case west:
+		return -1, 0
+	}
+	return 1, 0
+}
+
 func (s *snake) reduceSize() bool {
 	if len(s.position) <= 2 {
 		return true
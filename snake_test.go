@@ -2,6 +2,7 @@ package snake
 
 import (
 	"fmt"
+	"math/rand"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -10,7 +11,8 @@ import (
 func TestNewSnake(t *testing.T) {
 	b := newBoard(10, 10)
 	id := ID(7)
-	s := newSnake(b, id)
+	s, err := newSnake(b, id, rand.New(rand.NewSource(1)))
+	require.NoError(t, err)
 	require.Equal(t, 2, len(s.position))
 	for _, pos := range s.position {
 		require.True(t, b[pos.y][pos.x] == int8(id), fmt.Sprintf("%+v\n", b))
@@ -46,24 +48,41 @@ func TestSnake(t *testing.T) {
 	require.Equal(t, Position{x: 5, y: 5}, s.tail())
 	require.Equal(t, east, s.getDir())
 
-	m := Move{move: []float64{0, 1, 0}, ID: ID(7)}
+	m := Move{Move: []float64{0, 1, 0}, ID: ID(7)}
 	require.Equal(t, Position{x: 7, y: 5}, s.newHeadPos(m))
 
-	m = Move{move: []float64{1, 0, 0}, ID: ID(7)}
+	m = Move{Move: []float64{1, 0, 0}, ID: ID(7)}
 	require.Equal(t, Position{x: 6, y: 4}, s.newHeadPos(m))
 
-	m = Move{move: []float64{0, 0, 1}, ID: ID(7)}
+	m = Move{Move: []float64{0, 0, 1}, ID: ID(7)}
 	require.Equal(t, Position{x: 6, y: 6}, s.newHeadPos(m))
 
-	m = Move{move: []float64{0, 1, 0}, ID: ID(7)}
+	m = Move{Move: []float64{0, 1, 0}, ID: ID(7)}
 	s.moveTo(s.newHeadPos(m), false)
 	require.Equal(t, Position{x: 7, y: 5}, s.head())
 	require.Equal(t, Position{x: 6, y: 5}, s.body())
 	require.Equal(t, Position{x: 6, y: 5}, s.tail())
 
-	m = Move{move: []float64{1, 0, 0}, ID: ID(7)}
+	m = Move{Move: []float64{1, 0, 0}, ID: ID(7)}
 	s.moveTo(s.newHeadPos(m), false)
 	require.Equal(t, Position{x: 7, y: 4}, s.head())
 	require.Equal(t, Position{x: 7, y: 5}, s.body())
 	require.Equal(t, Position{x: 7, y: 5}, s.tail())
 }
+
+func TestNewSnakeFull(t *testing.T) {
+	// only the cells at 1,1 and 2,1 are free
+	b := newBoard(5, 5)
+	for y := 1; y < 4; y++ {
+		for x := 1; x < 4; x++ {
+			b[y][x] = 3
+		}
+	}
+	b[1][1], b[1][2] = empty, empty
+	s, err := newSnake(b, 2, rand.New(rand.NewSource(1)))
+	require.NoError(t, err)
+	require.ElementsMatch(t, []Position{{x: 1, y: 1}, {x: 2, y: 1}}, s.position)
+
+	_, err = newSnake(b, 4, rand.New(rand.NewSource(1)))
+	require.Equal(t, ErrBoardFull, err)
+}
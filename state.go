@@ -0,0 +1,145 @@
+package snake
+
+import (
+	"encoding/json"
+	"sort"
+)
+
+// NewPosition returns the position of the cell at x, y
+func NewPosition(x, y int) Position {
+	return Position{x: x, y: y}
+}
+
+// X is the column of the position, 0 is the left wall
+func (p Position) X() int {
+	return p.x
+}
+
+// Y is the row of the position, 0 is the top wall
+func (p Position) Y() int {
+	return p.y
+}
+
+// Distance is the number of steps between p and q on an empty board
+func (p Position) Distance(q Position) int {
+	return abs(p.x-q.x) + abs(p.y-q.y)
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+type jsonPosition struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// MarshalJSON encodes the position as {"x":x,"y":y}
+func (p Position) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonPosition{X: p.x, Y: p.y})
+}
+
+// UnmarshalJSON decodes a position encoded by MarshalJSON
+func (p *Position) UnmarshalJSON(b []byte) error {
+	var j jsonPosition
+	if err := json.Unmarshal(b, &j); err != nil {
+		return err
+	}
+	p.x, p.y = j.X, j.Y
+	return nil
+}
+
+// Heading is the direction a snake moves in on the board
+type Heading string
+
+// The headings, north is up
+const (
+	North Heading = Heading(north)
+	South Heading = Heading(south)
+	East  Heading = Heading(east)
+	West  Heading = Heading(west)
+)
+
+// Vector returns the step on the board going in heading h
+func (h Heading) Vector() (dx, dy int) {
+	return direction(h).vector()
+}
+
+// SnakeInfo describes a snake on the board
+type SnakeInfo struct {
+	ID      ID         `json:"id"`
+	Head    Position   `json:"head"`
+	Body    []Position `json:"body"` // tail first, the head is the last position
+	Heading Heading    `json:"heading"`
+	Length  int        `json:"length"`
+}
+
+func (g *Game) snakeInfo(id ID, s *snake) SnakeInfo {
+	return SnakeInfo{
+		ID:      id,
+		Head:    s.head(),
+		Body:    append([]Position(nil), s.position...),
+		Heading: Heading(s.getDir()),
+		Length:  len(s.position),
+	}
+}
+
+// Snake returns the snake of a player, ok is false when the player is dead
+func (g *Game) Snake(id ID) (info SnakeInfo, ok bool) {
+	p, ok := g.Players[id]
+	if !ok {
+		return SnakeInfo{}, false
+	}
+	return g.snakeInfo(id, p.snake), true
+}
+
+// Snakes returns all snakes on the board in ID order
+func (g *Game) Snakes() []SnakeInfo {
+	snakes := make([]SnakeInfo, 0, len(g.Players))
+	for id, p := range g.Players {
+		snakes = append(snakes, g.snakeInfo(id, p.snake))
+	}
+	sort.Slice(snakes, func(i, j int) bool { return snakes[i].ID < snakes[j].ID })
+	return snakes
+}
+
+// Opponents returns all snakes but the player's own in ID order
+func (g *Game) Opponents(id ID) []SnakeInfo {
+	snakes := g.Snakes()
+	opponents := snakes[:0]
+	for _, s := range snakes {
+		if s.ID != id {
+			opponents = append(opponents, s)
+		}
+	}
+	return opponents
+}
+
+// Food returns the positions of the food on the board, row by row
+func (g *Game) Food() []Position {
+	var f []Position
+	for y, row := range g.board {
+		for x, cell := range row {
+			if cell == food {
+				f = append(f, Position{x: x, y: y})
+			}
+		}
+	}
+	return f
+}
+
+// Height returns the number of rows of the board, walls included
+func (g *Game) Height() int {
+	return len(g.board)
+}
+
+// Width returns the number of columns of the board, walls included
+func (g *Game) Width() int {
+	if len(g.board) == 0 {
+		return 0
+	}
+	return len(g.board[0])
+}
@@ -0,0 +1,60 @@
+package snake
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnakeInfo(t *testing.T) {
+	g := newHeadOnGame(ResolveByID, 3)
+	g.board[1][2] = food
+	g.board[5][8] = food
+	g.tick = 4
+
+	var gs GameState = g
+	self, ok := gs.Snake(2)
+	require.True(t, ok)
+	require.Equal(t, ID(2), self.ID)
+	require.Equal(t, NewPosition(4, 3), self.Head)
+	require.Equal(t, 4, self.Head.X())
+	require.Equal(t, 3, self.Head.Y())
+	require.Equal(t, East, self.Heading)
+	require.Equal(t, 3, self.Length)
+	require.Equal(t, NewPosition(2, 3), self.Body[0])
+
+	opponents := gs.Opponents(2)
+	require.Len(t, opponents, 1)
+	require.Equal(t, ID(3), opponents[0].ID)
+	require.Equal(t, West, opponents[0].Heading)
+	require.Equal(t, NewPosition(6, 3), opponents[0].Head)
+
+	require.Equal(t, []Position{NewPosition(2, 1), NewPosition(8, 5)}, gs.Food())
+	require.Equal(t, 4, gs.Tick())
+	require.Equal(t, 7, gs.Height())
+	require.Equal(t, 11, gs.Width())
+
+	g.removePlayer(3)
+	_, ok = gs.Snake(3)
+	require.False(t, ok)
+	require.Empty(t, gs.Opponents(2))
+}
+
+func TestPositionJSON(t *testing.T) {
+	b, err := json.Marshal(NewPosition(3, 4))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"x":3,"y":4}`, string(b))
+	var p Position
+	require.NoError(t, json.Unmarshal(b, &p))
+	require.Equal(t, NewPosition(3, 4), p)
+}
+
+func TestHeadingVector(t *testing.T) {
+	for h, v := range map[Heading][2]int{North: {0, -1}, South: {0, 1}, West: {-1, 0}, East: {1, 0}} {
+		dx, dy := h.Vector()
+		require.Equal(t, v, [2]int{dx, dy}, h)
+	}
+	require.Equal(t, 7, NewPosition(1, 5).Distance(NewPosition(4, 1)))
+	require.Zero(t, NewPosition(2, 2).Distance(NewPosition(2, 2)))
+}
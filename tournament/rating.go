@@ -0,0 +1,135 @@
+package tournament
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"text/tabwriter"
+)
+
+// DefaultElo is the rating of an entrant that never played
+const DefaultElo = 1500
+
+// Rating is the standing of an entrant over all tournaments
+type Rating struct {
+	Elo    float64 `json:"elo"`
+	Games  int     `json:"games"`
+	Wins   int     `json:"wins"`
+	Losses int     `json:"losses"`
+	Draws  int     `json:"draws"`
+}
+
+// Ratings are keyed on the entrant name
+type Ratings map[string]Rating
+
+// Get returns the rating of an entrant, entrants that never played have the DefaultElo
+func (r Ratings) Get(name string) Rating {
+	if rt, ok := r[name]; ok {
+		return rt
+	}
+	return Rating{Elo: DefaultElo}
+}
+
+// update applies the result of a ranked match.
+// A free for all match counts as a 1v1 between every pair of entrants,
+// the K factor is divided over the opponents so a match weighs the same whatever its size
+func (r Ratings) update(placements []Placement, k float64) {
+	if k == 0 {
+		k = 32
+	}
+	k /= float64(len(placements) - 1)
+	delta := make(map[string]float64, len(placements))
+	for _, a := range placements {
+		ra := r.Get(a.Name).Elo
+		for _, b := range placements {
+			if a.Name == b.Name {
+				continue
+			}
+			rb := r.Get(b.Name).Elo
+			expected := 1 / (1 + math.Pow(10, (rb-ra)/400))
+			delta[a.Name] += k * (score(a, b) - expected)
+		}
+	}
+	for _, p := range placements {
+		rt := r.Get(p.Name)
+		rt.Elo += delta[p.Name]
+		rt.Games++
+		switch {
+		case p.Rank > 0:
+			rt.Losses++
+		case len(placements) > 1 && placements[1].Rank == 0:
+			rt.Draws++
+		default:
+			rt.Wins++
+		}
+		r[p.Name] = rt
+	}
+}
+
+// LoadRatings reads ratings saved with Save, a missing file gives empty ratings
+func LoadRatings(path string) (Ratings, error) {
+	r := make(Ratings)
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	if err := json.NewDecoder(file).Decode(&r); err != nil {
+		return nil, fmt.Errorf("reading ratings %s: %v", path, err)
+	}
+	return r, nil
+}
+
+// Save writes the ratings as json to path
+func (r Ratings) Save(path string) error {
+	b, err := json.MarshalIndent(r, "", "\t")
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(b); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Standing is a line of the leaderboard
+type Standing struct {
+	Name string
+	Rating
+}
+
+// Leaderboard returns the entrants sorted from highest to lowest elo
+func (r Ratings) Leaderboard() []Standing {
+	board := make([]Standing, 0, len(r))
+	for name, rt := range r {
+		board = append(board, Standing{Name: name, Rating: rt})
+	}
+	sort.Slice(board, func(i, j int) bool {
+		if board[i].Elo != board[j].Elo {
+			return board[i].Elo > board[j].Elo
+		}
+		return board[i].Name < board[j].Name
+	})
+	return board
+}
+
+// WriteLeaderboard prints the leaderboard as a table
+func (r Ratings) WriteLeaderboard(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tplayer\telo\tgames\twins\tdraws\tlosses")
+	for i, s := range r.Leaderboard() {
+		fmt.Fprintf(tw, "%d\t%s\t%.0f\t%d\t%d\t%d\t%d\n", i+1, s.Name, s.Elo, s.Games, s.Wins, s.Draws, s.Losses)
+	}
+	return tw.Flush()
+}
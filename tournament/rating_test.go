@@ -0,0 +1,50 @@
+package tournament
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRatingsUpdate(t *testing.T) {
+	r := make(Ratings)
+	r.update([]Placement{{Name: "a", Rank: 0}, {Name: "b", Rank: 1}}, 32)
+	require.InDelta(t, 1516, r.Get("a").Elo, 1e-9)
+	require.InDelta(t, 1484, r.Get("b").Elo, 1e-9)
+	require.Equal(t, 1, r.Get("a").Wins)
+	require.Equal(t, 1, r.Get("b").Losses)
+
+	r.update([]Placement{{Name: "a", Rank: 0}, {Name: "b", Rank: 0}}, 32)
+	require.Equal(t, 1, r.Get("a").Draws)
+	require.True(t, r.Get("a").Elo < 1516)
+	require.True(t, r.Get("b").Elo > 1484)
+}
+
+func TestRatingsSaveLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ratings")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "ratings.json")
+
+	r, err := LoadRatings(path)
+	require.NoError(t, err)
+	require.Empty(t, r)
+
+	r.update([]Placement{{Name: "a", Rank: 0}, {Name: "b", Rank: 1}, {Name: "c", Rank: 2}}, 32)
+	require.NoError(t, r.Save(path))
+	loaded, err := LoadRatings(path)
+	require.NoError(t, err)
+	require.Equal(t, r, loaded)
+
+	var buf bytes.Buffer
+	require.NoError(t, loaded.WriteLeaderboard(&buf))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 4)
+	require.Contains(t, lines[1], "a")
+	require.Contains(t, lines[3], "c")
+}
@@ -0,0 +1,242 @@
+package tournament
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/wouterbeets/snake"
+)
+
+// Entrant is a player taking part in a tournament
+// New is called for every match so players don't share state between games
+type Entrant struct {
+	Name string
+	New  func() snake.Player
+}
+
+// Placement is how an entrant finished a match
+type Placement struct {
+	Name string
+	// Rank starts at 0 for the winner, entrants that finished equal share a rank
+	Rank   int
+	Alive  bool
+	Length int
+	// Tick is the tick the snake died, or the number of ticks played if it survived
+	Tick int
+}
+
+// Tournament plays matches between entrants and keeps their ratings up to date
+type Tournament struct {
+	Entrants []Entrant
+	// Config is used for every match, match i is played with Config.Seed+i.
+	// Players is ignored
+	Config snake.GameConfig
+	// MaxTicks ends a match when no snake died before
+	MaxTicks int
+	Ratings  Ratings
+	// K is the elo K factor, 32 is used when it is 0
+	K float64
+
+	played map[string]map[string]bool
+	points map[string]float64
+	match  int
+}
+
+// New returns a tournament with a fresh set of ratings
+func New(entrants []Entrant, cfg snake.GameConfig, maxTicks int) *Tournament {
+	return &Tournament{
+		Entrants: entrants,
+		Config:   cfg,
+		MaxTicks: maxTicks,
+		Ratings:  make(Ratings),
+	}
+}
+
+// Play runs one match between the entrants and updates the ratings
+func (t *Tournament) Play(entrants []Entrant) ([]Placement, error) {
+	if len(entrants) < 2 {
+		return nil, errors.New("a match needs at least two entrants")
+	}
+	cfg := t.Config
+	cfg.Seed += int64(t.match)
+	cfg.Players = nil
+	for _, e := range entrants {
+		cfg.Players = append(cfg.Players, e.New())
+	}
+	t.match++
+	g, err := snake.NewGameWithConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := g.Run(context.Background(), snake.RunOptions{MaxTicks: t.MaxTicks}); err != nil {
+		return nil, err
+	}
+
+	placements := make([]Placement, len(entrants))
+	for i, e := range entrants {
+		id := snake.ID(i + 2)
+		p := Placement{Name: e.Name, Alive: true, Length: g.PlayerLen(id), Tick: g.Tick()}
+		if d, ok := g.Death(id); ok {
+			p = Placement{Name: e.Name, Length: d.Length, Tick: d.Tick}
+		}
+		placements[i] = p
+	}
+	rank(placements)
+	t.record(placements)
+	return placements, nil
+}
+
+// rank sorts the placements from first to last and sets their rank.
+// The longest lasting snake wins, length breaks ties
+func rank(p []Placement) {
+	better := func(a, b Placement) bool {
+		if a.Alive != b.Alive {
+			return a.Alive
+		}
+		if a.Tick != b.Tick {
+			return a.Tick > b.Tick
+		}
+		return a.Length > b.Length
+	}
+	sort.SliceStable(p, func(i, j int) bool { return better(p[i], p[j]) })
+	for i := range p {
+		p[i].Rank = i
+		if i > 0 && !better(p[i-1], p[i]) {
+			p[i].Rank = p[i-1].Rank
+		}
+	}
+}
+
+// record updates the ratings and tournament points of a ranked match
+func (t *Tournament) record(placements []Placement) {
+	if t.played == nil {
+		t.played = make(map[string]map[string]bool)
+	}
+	if t.points == nil {
+		t.points = make(map[string]float64)
+	}
+	if t.Ratings == nil {
+		t.Ratings = make(Ratings)
+	}
+	for _, a := range placements {
+		for _, b := range placements {
+			if a.Name == b.Name {
+				continue
+			}
+			if t.played[a.Name] == nil {
+				t.played[a.Name] = make(map[string]bool)
+			}
+			t.played[a.Name][b.Name] = true
+			t.points[a.Name] += score(a, b)
+		}
+	}
+	t.Ratings.update(placements, t.K)
+}
+
+// score is 1 if a beat b, 0.5 for a draw and 0 if a lost
+func score(a, b Placement) float64 {
+	switch {
+	case a.Rank < b.Rank:
+		return 1
+	case a.Rank == b.Rank:
+		return 0.5
+	}
+	return 0
+}
+
+// Points returns the points an entrant scored in this tournament,
+// every opponent beaten in a match is worth 1 and every draw 0.5
+func (t *Tournament) Points(name string) float64 {
+	return t.points[name]
+}
+
+// RoundRobin plays a match for every group of size entrants, repeat times.
+// A size of 0 or larger than the number of entrants plays free for all matches
+func (t *Tournament) RoundRobin(size, repeat int) error {
+	if size <= 0 || size > len(t.Entrants) {
+		size = len(t.Entrants)
+	}
+	groups := combinations(len(t.Entrants), size)
+	for r := 0; r < repeat; r++ {
+		for _, group := range groups {
+			entrants := make([]Entrant, len(group))
+			for i, e := range group {
+				entrants[i] = t.Entrants[e]
+			}
+			if _, err := t.Play(entrants); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// combinations returns all sets of k indices out of n
+func combinations(n, k int) (sets [][]int) {
+	set := make([]int, 0, k)
+	var walk func(start int)
+	walk = func(start int) {
+		if len(set) == k {
+			sets = append(sets, append([]int(nil), set...))
+			return
+		}
+		for i := start; i <= n-(k-len(set)); i++ {
+			set = append(set, i)
+			walk(i + 1)
+			set = set[:len(set)-1]
+		}
+	}
+	walk(0)
+	return
+}
+
+// Swiss plays rounds in which entrants with similar points meet in groups of size.
+// In 1v1 rounds entrants don't meet the same opponent twice while they can avoid it,
+// entrants left over when the groups are made sit the round out
+func (t *Tournament) Swiss(rounds, size int) error {
+	if size < 2 || size > len(t.Entrants) {
+		size = len(t.Entrants)
+	}
+	for r := 0; r < rounds; r++ {
+		order := make([]Entrant, len(t.Entrants))
+		copy(order, t.Entrants)
+		sort.SliceStable(order, func(i, j int) bool {
+			a, b := order[i].Name, order[j].Name
+			if t.points[a] != t.points[b] {
+				return t.points[a] > t.points[b]
+			}
+			return t.Ratings.Get(a).Elo > t.Ratings.Get(b).Elo
+		})
+		for _, group := range t.pair(order, size) {
+			if _, err := t.Play(group); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// pair splits the ordered entrants in groups of size
+func (t *Tournament) pair(order []Entrant, size int) (groups [][]Entrant) {
+	if size != 2 {
+		for i := 0; i+size <= len(order); i += size {
+			groups = append(groups, order[i:i+size])
+		}
+		return
+	}
+	left := append([]Entrant(nil), order...)
+	for len(left) >= 2 {
+		a := left[0]
+		opponent := 1
+		for i := 1; i < len(left); i++ {
+			if !t.played[a.Name][left[i].Name] {
+				opponent = i
+				break
+			}
+		}
+		groups = append(groups, []Entrant{a, left[opponent]})
+		left = append(left[1:opponent], left[opponent+1:]...)
+	}
+	return
+}
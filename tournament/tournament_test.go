@@ -0,0 +1,75 @@
+package tournament
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/wouterbeets/snake"
+)
+
+func randomEntrants(names ...string) []Entrant {
+	var entrants []Entrant
+	for _, n := range names {
+		entrants = append(entrants, Entrant{Name: n, New: func() snake.Player { return &snake.Random{} }})
+	}
+	return entrants
+}
+
+func TestRank(t *testing.T) {
+	p := []Placement{
+		{Name: "a", Tick: 10, Length: 3},
+		{Name: "b", Alive: true, Tick: 50, Length: 2},
+		{Name: "c", Tick: 10, Length: 3},
+		{Name: "d", Tick: 20, Length: 2},
+	}
+	rank(p)
+	var names []string
+	var ranks []int
+	for _, pl := range p {
+		names = append(names, pl.Name)
+		ranks = append(ranks, pl.Rank)
+	}
+	require.Equal(t, []string{"b", "d", "a", "c"}, names)
+	require.Equal(t, []int{0, 1, 2, 2}, ranks)
+}
+
+func TestCombinations(t *testing.T) {
+	require.Equal(t, [][]int{{0, 1}, {0, 2}, {1, 2}}, combinations(3, 2))
+	require.Equal(t, [][]int{{0, 1, 2}}, combinations(3, 3))
+	require.Len(t, combinations(5, 2), 10)
+}
+
+func TestRoundRobin(t *testing.T) {
+	tour := New(randomEntrants("a", "b", "c"), snake.GameConfig{Height: 15, Width: 15, NbFoodOnMap: 3, Seed: 1}, 30)
+	require.NoError(t, tour.RoundRobin(2, 2))
+	var elo float64
+	for _, name := range []string{"a", "b", "c"} {
+		r := tour.Ratings.Get(name)
+		require.Equal(t, 4, r.Games)
+		require.Equal(t, r.Games, r.Wins+r.Draws+r.Losses)
+		elo += r.Elo
+	}
+	require.InDelta(t, 3*DefaultElo, elo, 1e-9)
+	require.InDelta(t, 6, tour.Points("a")+tour.Points("b")+tour.Points("c"), 1e-9)
+
+	require.NoError(t, tour.RoundRobin(0, 1))
+	require.Equal(t, 5, tour.Ratings.Get("a").Games)
+}
+
+func TestSwissPairing(t *testing.T) {
+	tour := New(randomEntrants("a", "b", "c", "d", "e"), snake.GameConfig{Height: 15, Width: 15, Seed: 1}, 30)
+	tour.played = map[string]map[string]bool{"a": {"b": true}}
+	groups := tour.pair(tour.Entrants, 2)
+	require.Len(t, groups, 2)
+	require.Equal(t, "a", groups[0][0].Name)
+	require.Equal(t, "c", groups[0][1].Name)
+	require.Equal(t, "b", groups[1][0].Name)
+	require.Equal(t, "d", groups[1][1].Name)
+
+	require.NoError(t, tour.Swiss(3, 2))
+	games := 0
+	for _, r := range tour.Ratings {
+		games += r.Games
+	}
+	require.Equal(t, 3*2*2, games)
+}
@@ -0,0 +1,105 @@
+package snake
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// VisionProvider computes what a snake sees, it is what the players get from Inputs
+type VisionProvider interface {
+	// Name is the name the provider is registered under
+	Name() string
+	// Size is the number of values See returns
+	Size() int
+	See(g *Game, id ID) []float64
+}
+
+// VisionPlayer is a player that picks its own vision provider instead of the game's.
+// Returning nil uses the provider of the game
+type VisionPlayer interface {
+	Player
+	VisionProvider() VisionProvider
+}
+
+var (
+	visionsMu sync.RWMutex
+	visions   = make(map[string]VisionProvider)
+)
+
+// RegisterVision makes a vision provider available by its name.
+// It panics when a provider with the same name is already registered
+func RegisterVision(v VisionProvider) {
+	visionsMu.Lock()
+	defer visionsMu.Unlock()
+	if _, ok := visions[v.Name()]; ok {
+		panic("snake: vision " + v.Name() + " registered twice")
+	}
+	visions[v.Name()] = v
+}
+
+// LookupVision returns the vision provider registered under name
+func LookupVision(name string) (VisionProvider, error) {
+	visionsMu.RLock()
+	defer visionsMu.RUnlock()
+	v, ok := visions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown vision %q", name)
+	}
+	return v, nil
+}
+
+// Visions returns the names of all registered vision providers
+func Visions() []string {
+	visionsMu.RLock()
+	defer visionsMu.RUnlock()
+	names := make([]string, 0, len(visions))
+	for name := range visions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DefaultVision is used by games that don't set a vision provider
+var DefaultVision VisionProvider = cellVision{name: "sensor", size: 25, see: (*Game).SensorVision}
+
+func init() {
+	RegisterVision(DefaultVision)
+	RegisterVision(cellVision{name: "primordial", size: 3, see: (*Game).PrimordialVision})
+	RegisterVision(cellVision{name: "second", size: 10, see: (*Game).SecondLayerVision})
+	RegisterVision(cellVision{name: "third", size: 21, see: (*Game).ThirdLayerVision})
+}
+
+// cellVision turns one of the board cell visions into a provider
+type cellVision struct {
+	name string
+	size int
+	see  func(g *Game, id ID) []int8
+}
+
+func (c cellVision) Name() string {
+	return c.name
+}
+
+func (c cellVision) Size() int {
+	return c.size
+}
+
+func (c cellVision) See(g *Game, id ID) []float64 {
+	cells := c.see(g, id)
+	in := make([]float64, len(cells))
+	for i := range cells {
+		in[i] = float64(cells[i])
+	}
+	return in
+}
+
+// Inputs returns what the player sees through its vision provider
+func (g *Game) Inputs(id ID) []float64 {
+	v := g.Players[id].vision
+	if v == nil {
+		v = DefaultVision
+	}
+	return v.See(g, id)
+}
@@ -0,0 +1,62 @@
+package snake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// sightedPlayer goes straight and sees through its own vision provider
+type sightedPlayer struct {
+	straightPlayer
+	vision VisionProvider
+}
+
+func (s *sightedPlayer) VisionProvider() VisionProvider {
+	return s.vision
+}
+
+func TestVisionSizes(t *testing.T) {
+	for _, name := range Visions() {
+		v, err := LookupVision(name)
+		require.NoError(t, err)
+		require.Equal(t, name, v.Name())
+		// check every heading
+		for _, pos := range [][]Position{
+			{{x: 10, y: 11}, {x: 10, y: 10}},
+			{{x: 10, y: 10}, {x: 10, y: 11}},
+			{{x: 10, y: 10}, {x: 11, y: 10}},
+			{{x: 11, y: 10}, {x: 10, y: 10}},
+		} {
+			g := &Game{board: newBoard(20, 20), Players: map[ID]playerInfo{
+				2: {snake: &snake{position: pos}, vision: v},
+			}}
+			require.Len(t, v.See(g, 2), v.Size(), name)
+			require.Len(t, g.Inputs(2), v.Size(), name)
+		}
+	}
+}
+
+func TestVisionRegistry(t *testing.T) {
+	require.Contains(t, Visions(), "sensor")
+	require.Contains(t, Visions(), "primordial")
+	_, err := LookupVision("x-ray")
+	require.Error(t, err)
+	require.Panics(t, func() { RegisterVision(DefaultVision) })
+}
+
+func TestVisionPerPlayer(t *testing.T) {
+	primordial, err := LookupVision("primordial")
+	require.NoError(t, err)
+	g, err := NewGameWithConfig(GameConfig{
+		Height:  20,
+		Width:   20,
+		Players: []Player{&straightPlayer{}, &sightedPlayer{vision: primordial}, &sightedPlayer{}},
+		Seed:    1,
+	})
+	require.NoError(t, err)
+	require.Len(t, g.Inputs(2), DefaultVision.Size())
+	require.Len(t, g.Inputs(3), primordial.Size())
+	require.Len(t, g.Inputs(4), DefaultVision.Size())
+	require.Equal(t, g.Vision(2), g.SensorVision(2))
+}
@@ -0,0 +1,86 @@
+package snake
+
+import "fmt"
+
+func init() {
+	RegisterVision(WindowVision{Side: 11})
+}
+
+// Window returns the cells of a side by side square centred on the head of the player,
+// rotated so the snake always heads up. Cells are encoded like OneHotVision, the values
+// are laid out channel by channel, then row by row from the top: i = c*side*side + row*side + col.
+// Even sides are rounded up so the head is in the middle, a side of 0 or less returns
+// a window in which the whole board fits whatever the heading
+func (g *Game) Window(id ID, side int) []float64 {
+	side = g.windowSide(side)
+	s := g.Players[id].snake
+	head := s.head()
+	fx, fy := s.getDir().vector()
+	rx, ry := -fy, fx
+
+	half := side / 2
+	in := make([]float64, Channels*side*side)
+	for row := 0; row < side; row++ {
+		ahead := half - row
+		for col := 0; col < side; col++ {
+			right := col - half
+			x := head.x + fx*ahead + rx*right
+			y := head.y + fy*ahead + ry*right
+			c := g.Channel(id, y, x)
+			in[int(c)*side*side+row*side+col] = 1
+		}
+	}
+	return in
+}
+
+// windowSide returns the side Window uses for side
+func (g *Game) windowSide(side int) int {
+	if side <= 0 {
+		side = len(g.board)
+		if len(g.board[0]) > side {
+			side = len(g.board[0])
+		}
+		side = 2*side - 1
+	}
+	if side%2 == 0 {
+		side++
+	}
+	return side
+}
+
+// WindowVision is a VisionProvider returning the Window around the head
+type WindowVision struct {
+	// Side is the side of the window, even sides are rounded up like Window does.
+	// It must be larger than 0, the size of a window over the whole board depends on the game
+	Side int
+}
+
+// side returns the side of the windows w sees
+func (w WindowVision) side() int {
+	if w.Side <= 0 {
+		panic(fmt.Sprintf("snake: window vision side %d is not larger than 0", w.Side))
+	}
+	if w.Side%2 == 0 {
+		return w.Side + 1
+	}
+	return w.Side
+}
+
+// Name implements VisionProvider
+func (w WindowVision) Name() string {
+	if w.Side == 11 {
+		return "window"
+	}
+	return fmt.Sprintf("window%d", w.Side)
+}
+
+// Size implements VisionProvider
+func (w WindowVision) Size() int {
+	side := w.side()
+	return Channels * side * side
+}
+
+// See implements VisionProvider
+func (w WindowVision) See(g *Game, id ID) []float64 {
+	return g.Window(id, w.side())
+}
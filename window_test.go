@@ -0,0 +1,78 @@
+package snake
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// windowCell returns the channel of a cell of a window
+func windowCell(in []float64, side, row, col int) Channel {
+	for c := 0; c < Channels; c++ {
+		if in[c*side*side+row*side+col] == 1 {
+			return Channel(c)
+		}
+	}
+	return -1
+}
+
+func TestWindow(t *testing.T) {
+	// snake 2 heads east from (4,3), snake 3 heads west with its head at (6,3)
+	g := newHeadOnGame(ResolveByID, 3)
+	g.board[1][4] = food
+	g.board[1][6] = food
+	in := g.Window(2, 5)
+	require.Len(t, in, Channels*5*5)
+	require.Equal(t, ChannelSelfHead, windowCell(in, 5, 2, 2))
+	// behind is down
+	require.Equal(t, ChannelSelfBody, windowCell(in, 5, 3, 2))
+	// ahead is up
+	require.Equal(t, ChannelEmpty, windowCell(in, 5, 1, 2))
+	require.Equal(t, ChannelEnemyHead, windowCell(in, 5, 0, 2))
+	// north of the head is to the left
+	require.Equal(t, ChannelFood, windowCell(in, 5, 2, 0))
+	// south of the head is to the right
+	require.Equal(t, ChannelEmpty, windowCell(in, 5, 2, 3))
+
+	// snake 3 heads west so north is to its right
+	in = g.Window(3, 5)
+	require.Equal(t, ChannelSelfHead, windowCell(in, 5, 2, 2))
+	require.Equal(t, ChannelEnemyHead, windowCell(in, 5, 0, 2))
+	require.Equal(t, ChannelFood, windowCell(in, 5, 2, 4))
+	require.Equal(t, ChannelEmpty, windowCell(in, 5, 2, 0))
+
+	in = g.Window(2, 7)
+	require.Equal(t, ChannelWall, windowCell(in, 7, 3, 0))
+}
+
+func TestWindowWholeBoard(t *testing.T) {
+	g := newHeadOnGame(ResolveByID, 3)
+	side := g.windowSide(0)
+	require.Equal(t, 2*11-1, side)
+	in := g.Window(2, 0)
+	require.Len(t, in, Channels*side*side)
+	counts := make(map[Channel]int)
+	for row := 0; row < side; row++ {
+		for col := 0; col < side; col++ {
+			counts[windowCell(in, side, row, col)]++
+		}
+	}
+	// all snake cells of the board are in the window
+	require.Equal(t, 1, counts[ChannelSelfHead])
+	require.Equal(t, 2, counts[ChannelSelfBody])
+	require.Equal(t, 1, counts[ChannelEnemyHead])
+	require.Equal(t, 2, counts[ChannelEnemyBody])
+	require.Equal(t, 3, g.windowSide(3))
+	require.Equal(t, 5, g.windowSide(4))
+}
+
+func TestWindowVisionSize(t *testing.T) {
+	g := newHeadOnGame(ResolveByID, 3)
+	for _, side := range []int{1, 5, 10, 11} {
+		w := WindowVision{Side: side}
+		require.Len(t, w.See(g, 2), w.Size(), "side %d", side)
+	}
+	require.Equal(t, Channels*11*11, WindowVision{Side: 10}.Size())
+	require.Panics(t, func() { WindowVision{}.Size() })
+	require.Panics(t, func() { WindowVision{Side: -3}.See(g, 2) })
+}